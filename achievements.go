@@ -0,0 +1,188 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// Something worth celebrating, e.g. "Night owl: a bird came by in the middle of the night".
+type achievement struct {
+	id          string
+	name        string
+	description string
+}
+
+// The achievements everyone can earn, one per species aside. Ids are what's saved, so they must never change.
+var milestoneAchievements = []achievement{
+	{id: "dawn-song", name: "Early riser", description: "hear a song at dawn"},
+	{id: "full-feeder", name: "Full house", description: "five birds on the feeder at once"},
+	{id: "night-visitor", name: "Night owl", description: "a bird comes by in the middle of the night"},
+	{id: "never-empty", name: "Well stocked", description: "the feeder doesn't run empty for a week"},
+}
+
+// Every achievement, including a first sighting for each species that can turn up in any region.
+func allAchievements() []achievement {
+	achievements := []achievement{}
+
+	seen := map[string]bool{}
+	for _, region := range regions {
+		for _, species := range regionCatalogs[region].species {
+			if seen[species.Name()] {
+				continue
+			}
+			seen[species.Name()] = true
+
+			achievements = append(achievements, achievement{
+				id:          sightingAchievementID(species),
+				name:        "First " + species.Name(),
+				description: "spot a " + species.Name() + " at the feeder",
+			})
+		}
+	}
+
+	return append(achievements, milestoneAchievements...)
+}
+
+func sightingAchievementID(species species) string {
+	return "sighting:" + species.Name()
+}
+
+// A short-lived notice of an achievement just earned.
+type achievementToast struct {
+	achievement achievement
+	endTime     time.Time
+}
+
+type achievementTracker struct {
+	// When each achievement was earned, by id.
+	earned map[string]time.Time
+
+	// How long the feeder has been kept stocked since it was last empty, counting only time spent running, so days
+	// with Feedr closed don't count towards a week.
+	stockedFor time.Duration
+
+	// Toasts waiting to be shown, the first of which is showing.
+	toasts []*achievementToast
+
+	imd  *imdraw.IMDraw
+	text *text.Text
+}
+
+var achievements = &achievementTracker{earned: map[string]time.Time{}}
+
+// Start listening for anything worth an achievement.
+func (tracker *achievementTracker) Initialize() {
+	simulationEvents.subscribe(tracker.onEvent)
+}
+
+func (tracker *achievementTracker) onEvent(event simulationEvent) {
+	switch event.kind {
+	case birdArrived:
+		tracker.earn(sightingAchievementID(event.species))
+
+		// The rare visitor that beats defaultNumChancesOfNightBird.
+		if getTimeOfDay() == "night" {
+			tracker.earn("night-visitor")
+		}
+	case songHeard:
+		if getDayPhase(event.time) == dawn {
+			tracker.earn("dawn-song")
+		}
+	case feederEmptied:
+		tracker.stockedFor = 0
+		saveSettings()
+	}
+}
+
+// Check anything that comes from how the scene is, rather than from something happening.
+func (tracker *achievementTracker) update(birds []*bird, elapsed float64) {
+	onFeeder := 0
+	for _, bird := range birds {
+		if !bird.entering && !bird.exiting && !bird.removed && !bird.perch.waiting {
+			onFeeder++
+		}
+	}
+
+	if onFeeder >= fullFeederBirdCount {
+		tracker.earn("full-feeder")
+	}
+
+	if !context.Seed().Finished {
+		tracker.stockedFor += time.Duration(elapsed * float64(time.Second))
+	}
+
+	if tracker.stockedFor >= neverEmptyLength {
+		tracker.earn("never-empty")
+	}
+
+	// Move on to the next toast once the one showing has had its time.
+	if len(tracker.toasts) > 0 && time.Now().After(tracker.toasts[0].endTime) {
+		tracker.toasts = tracker.toasts[1:]
+		if len(tracker.toasts) > 0 {
+			tracker.toasts[0].endTime = time.Now().Add(achievementToastLength)
+		}
+	}
+}
+
+func (tracker *achievementTracker) earn(id string) {
+	if _, found := tracker.earned[id]; found {
+		return
+	}
+
+	for _, achievement := range allAchievements() {
+		if achievement.id != id {
+			continue
+		}
+
+		tracker.earned[id] = time.Now()
+		tracker.toasts = append(tracker.toasts, &achievementToast{achievement: achievement, endTime: time.Now().Add(achievementToastLength)})
+		saveSettings()
+		return
+	}
+}
+
+// Draw the toast showing, at the top of the screen and out of the way of the birds.
+func (tracker *achievementTracker) draw(target pixel.Target) {
+	if len(tracker.toasts) == 0 {
+		return
+	}
+
+	if tracker.text == nil {
+		tracker.imd = imdraw.New(nil)
+		tracker.text = text.New(pixel.ZV, text.NewAtlas(basicfont.Face7x13, text.ASCII))
+	}
+	imd := tracker.imd
+	imd.Clear()
+	tracker.text.Clear()
+
+	toast := tracker.toasts[0]
+	title := "Achievement: " + toast.achievement.name
+	description := toast.achievement.description
+
+	lineHeight := tracker.text.Atlas().LineHeight() * captionTextScale
+	width := math.Max(tracker.text.BoundsOf(title).W(), tracker.text.BoundsOf(description).W())*captionTextScale + captionPadding*2
+	height := lineHeight*2 + captionPadding*2
+
+	min := pixel.V(layout.visibleBounds.Center().X-width/2, layout.visibleBounds.Max.Y-height-captionPadding*3)
+
+	imd.Color = color.RGBA{A: 190}
+	imd.Push(min, min.Add(pixel.V(width, height)))
+	imd.Rectangle(0)
+	imd.Draw(target)
+
+	tracker.text.Dot = min.Add(pixel.V(captionPadding, captionPadding+lineHeight+lineHeight/4)).Scaled(1 / captionTextScale)
+	tracker.text.Color = color.RGBA{R: 250, G: 220, B: 120, A: 255}
+	tracker.text.WriteString(title)
+
+	tracker.text.Dot = min.Add(pixel.V(captionPadding, captionPadding+lineHeight/4)).Scaled(1 / captionTextScale)
+	tracker.text.Color = color.White
+	tracker.text.WriteString(description)
+
+	tracker.text.Draw(target, pixel.IM.Scaled(pixel.ZV, captionTextScale))
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	gocontext "context"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/speaker"
+)
+
+type ambienceCommand int
+
+const (
+	playAmbience ambienceCommand = iota
+	stopAmbience
+	switchAmbience
+	crossfadeAmbience
+)
+
+type ambienceMessage struct {
+	command    ambienceCommand
+	buffer     *beep.Buffer
+	fadeLength time.Duration
+}
+
+// Loops the background ambience on the ambience bus. A single goroutine owns the playing track and only ever wakes up
+// for a control message, so nothing spins while a loop plays (or while muted, which the mixer takes care of).
+type ambiencePlayer struct {
+	messages chan ambienceMessage
+	cancel   gocontext.CancelFunc
+
+	// Only ever touched by the player's goroutine.
+	buffer *beep.Buffer
+	track  *fader
+}
+
+var ambience = newAmbiencePlayer()
+
+func newAmbiencePlayer() *ambiencePlayer {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+
+	player := &ambiencePlayer{
+		messages: make(chan ambienceMessage, ambienceMessageQueueLength),
+		cancel:   cancel,
+	}
+
+	go player.run(ctx)
+
+	return player
+}
+
+// Start looping the track, unless it's already the one playing.
+func (player *ambiencePlayer) play(buffer *beep.Buffer) {
+	player.messages <- ambienceMessage{command: playAmbience, buffer: buffer}
+}
+
+func (player *ambiencePlayer) stop(fadeLength time.Duration) {
+	player.messages <- ambienceMessage{command: stopAmbience, fadeLength: fadeLength}
+}
+
+// Cut straight over to a new track.
+func (player *ambiencePlayer) switchTo(buffer *beep.Buffer) {
+	player.messages <- ambienceMessage{command: switchAmbience, buffer: buffer}
+}
+
+// Fade the current track out while the new one fades in.
+func (player *ambiencePlayer) crossfadeTo(buffer *beep.Buffer, fadeLength time.Duration) {
+	player.messages <- ambienceMessage{command: crossfadeAmbience, buffer: buffer, fadeLength: fadeLength}
+}
+
+// Stop the player for good.
+func (player *ambiencePlayer) close() {
+	player.cancel()
+}
+
+func (player *ambiencePlayer) run(ctx gocontext.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			player.fadeOut(0)
+			return
+		case message := <-player.messages:
+			player.handle(message)
+		}
+	}
+}
+
+func (player *ambiencePlayer) handle(message ambienceMessage) {
+	switch message.command {
+	case playAmbience:
+		if message.buffer != player.buffer || player.track == nil {
+			player.fadeOut(0)
+			player.fadeIn(message.buffer, 0)
+		}
+	case stopAmbience:
+		player.fadeOut(message.fadeLength)
+	case switchAmbience:
+		player.fadeOut(0)
+		player.fadeIn(message.buffer, 0)
+	case crossfadeAmbience:
+		player.fadeOut(message.fadeLength)
+		player.fadeIn(message.buffer, message.fadeLength)
+	}
+}
+
+// Fade the current track away. Once silent, it drops out of the mixer on its own.
+func (player *ambiencePlayer) fadeOut(fadeLength time.Duration) {
+	if player.track == nil {
+		return
+	}
+
+	speaker.Lock()
+	player.track.drainWhenSilent = true
+	player.track.fadeTo(0, fadeLength)
+	speaker.Unlock()
+
+	player.track = nil
+	player.buffer = nil
+}
+
+func (player *ambiencePlayer) fadeIn(buffer *beep.Buffer, fadeLength time.Duration) {
+	// Missing tracks are silent.
+	if buffer == nil {
+		return
+	}
+
+	player.buffer = buffer
+	player.track = &fader{Streamer: beep.Loop(-1, buffer.Streamer(0, buffer.Len())), gain: 0}
+
+	speaker.Lock()
+	player.track.fadeTo(1, fadeLength)
+	speaker.Unlock()
+
+	mixer.play(ambienceBus, player.track)
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faiface/pixel"
+	"github.com/pkg/errors"
+)
+
+type playbackMode int
+
+const (
+	loopPlayback playbackMode = iota
+	oncePlayback
+	pingPongPlayback
+)
+
+var playbackModeNames = map[string]playbackMode{
+	"":          loopPlayback,
+	"loop":      loopPlayback,
+	"once":      oncePlayback,
+	"pingpong":  pingPongPlayback,
+	"ping-pong": pingPongPlayback,
+}
+
+// A single frame of an animation: where it is on the sheet, how long it shows for (in seconds), and anything that
+// happens as it comes up (e.g. "peck").
+type animationFrame struct {
+	rect     pixel.Rect
+	duration float64
+	events   []string
+
+	// Frames trimmed of their empty edges on export remember the size they were cut from, and how far the middle of
+	// what's left is from the middle of that. Untrimmed frames leave these zero.
+	untrimmedSize pixel.Vec
+	trimOffset    pixel.Vec
+}
+
+// The size the frame was drawn at, before any trimming.
+func (frame animationFrame) sourceSize() pixel.Vec {
+	if frame.untrimmedSize == pixel.ZV {
+		return frame.rect.Size()
+	}
+
+	return frame.untrimmedSize
+}
+
+// A named animation, e.g. "Fly".
+type animationClip struct {
+	frames []animationFrame
+	mode   playbackMode
+}
+
+// How long it takes to play the clip through once (there and back again, for ping-pong clips).
+func (clip *animationClip) length() float64 {
+	length := 0.0
+	for _, frame := range clip.frames {
+		length += frame.duration
+	}
+
+	if clip.mode == pingPongPlayback && len(clip.frames) > 2 {
+		// The first and last frames aren't repeated on the way back.
+		length += length - clip.frames[0].duration - clip.frames[len(clip.frames)-1].duration
+	}
+
+	return length
+}
+
+// The index of the frame showing the given number of seconds into the clip.
+func (clip *animationClip) frameAt(counter float64) int {
+	length := clip.length()
+	if len(clip.frames) == 0 || length <= 0 {
+		return 0
+	}
+
+	// Clips played once stop on their last frame.
+	if clip.mode == oncePlayback && counter >= length {
+		return len(clip.frames) - 1
+	}
+
+	counter = math.Mod(counter, length)
+
+	order := clip.playbackOrder()
+	for _, index := range order {
+		if counter < clip.frames[index].duration {
+			return index
+		}
+
+		counter -= clip.frames[index].duration
+	}
+
+	return order[len(order)-1]
+}
+
+// The order frames are shown in over a single play through.
+func (clip *animationClip) playbackOrder() []int {
+	order := []int{}
+	for index := range clip.frames {
+		order = append(order, index)
+	}
+
+	if clip.mode == pingPongPlayback {
+		for index := len(clip.frames) - 2; index > 0; index-- {
+			order = append(order, index)
+		}
+	}
+
+	return order
+}
+
+// The JSON animation format, e.g.
+//
+//	{
+//	  "frameWidth": 43,
+//	  "frameDuration": 0.1,
+//	  "clips": {
+//	    "Eat": {"mode": "loop", "frames": [{"index": 1, "duration": 0.15, "events": ["peck"]}, {"index": 2}]},
+//	    "Fly": {"mode": "pingpong", "frames": [{"rect": {"x": 215, "y": 0, "w": 43, "h": 43}}]}
+//	  }
+//	}
+//
+// Frames either pick a cell from a strip of equally wide frames by index, or give their own rectangle (in image
+// coordinates, from the top left of the sheet). Frames without a duration use the file's frame duration.
+type animationFile struct {
+	FrameWidth    float64                      `json:"frameWidth"`
+	FrameDuration float64                      `json:"frameDuration"`
+	Clips         map[string]animationFileClip `json:"clips"`
+}
+
+type animationFileClip struct {
+	Mode   string               `json:"mode"`
+	Frames []animationFileFrame `json:"frames"`
+}
+
+type animationFileFrame struct {
+	Index    *int               `json:"index"`
+	Rect     *animationFileRect `json:"rect"`
+	Duration float64            `json:"duration"`
+	Events   []string           `json:"events"`
+}
+
+type animationFileRect struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+// Where a sprite sheet's own animation file lives, e.g. animationMap/northernCardinal.json for
+// sprites/northernCardinal.png. Failing that, an atlas exported alongside the sheet (sprites/northernCardinal.json).
+func animationFilePath(sheetPath string) (string, bool) {
+	name := strings.TrimSuffix(filepath.Base(sheetPath), filepath.Ext(sheetPath))
+
+	for _, path := range []string{
+		filepath.Join(filepath.Dir(animationMappingsFile), name+".json"),
+		strings.TrimSuffix(sheetPath, filepath.Ext(sheetPath)) + ".json",
+	} {
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// Load a species' sprite sheet and its animations. The animations can come from an Aseprite or TexturePacker export,
+// or from our own JSON format: the shared default animations, with anything in the species' own file on top. Sheets
+// without any of these fall back to the shared CSV mappings, with equally wide frames at the default frame rate.
+func loadBirdAnimations(sheetPath string) (pixel.Picture, map[string]*animationClip, error) {
+	layers := [][]byte{}
+	if contents, err := ioutil.ReadFile(defaultAnimationFile); err == nil {
+		layers = append(layers, contents)
+	}
+
+	descPath, found := animationFilePath(sheetPath)
+	if !found && len(layers) == 0 {
+		return loadAnimationSheet(sheetPath, animationMappingsFile, standardSpriteWidth)
+	}
+
+	sheet, err := loadPicture(sheetPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error loading animation sheet")
+	}
+
+	// Species without a file of their own use the defaults as they are.
+	if !found {
+		clips, err := parseAnimationFile(layers, sheet.Bounds())
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error loading animations from "+defaultAnimationFile)
+		}
+
+		return sheet, clips, nil
+	}
+
+	contents, err := ioutil.ReadFile(descPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error loading animations from "+descPath)
+	}
+
+	var clips map[string]*animationClip
+	switch sniffAnimationFormat(contents) {
+	case asepriteFormat:
+		clips, err = parseAsepriteSheet(contents, sheet.Bounds())
+	case texturePackerFormat:
+		clips, err = parseTexturePackerSheet(contents, sheet.Bounds())
+	default:
+		clips, err = parseAnimationFile(append(layers, contents), sheet.Bounds())
+	}
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error loading animations from "+descPath)
+	}
+
+	return sheet, clips, nil
+}
+
+// Read animation files, each on top of the last: a later file's frame width and duration win when it has them, and
+// its clips replace any of the same name.
+func parseAnimationFile(layers [][]byte, sheetBounds pixel.Rect) (map[string]*animationClip, error) {
+	file := animationFile{}
+	for _, contents := range layers {
+		if err := json.Unmarshal(contents, &file); err != nil {
+			return nil, err
+		}
+	}
+
+	if file.FrameDuration <= 0 {
+		file.FrameDuration = defaultBirdFrameRate
+	}
+
+	clips := map[string]*animationClip{}
+	for name, fileClip := range file.Clips {
+		mode, found := playbackModeNames[strings.ToLower(fileClip.Mode)]
+		if !found {
+			return nil, fmt.Errorf("unknown playback mode %q for %s", fileClip.Mode, name)
+		}
+
+		clip := &animationClip{mode: mode}
+		for _, fileFrame := range fileClip.Frames {
+			frame := animationFrame{duration: fileFrame.Duration, events: fileFrame.Events}
+			if frame.duration <= 0 {
+				frame.duration = file.FrameDuration
+			}
+
+			switch {
+			case fileFrame.Rect != nil:
+				frame.rect = fileFrame.Rect.sheetRect(sheetBounds)
+			case fileFrame.Index != nil && file.FrameWidth > 0:
+				x := float64(*fileFrame.Index) * file.FrameWidth
+				frame.rect = pixel.R(x, 0, x+file.FrameWidth, sheetBounds.H())
+			default:
+				return nil, fmt.Errorf("frame in %s needs either a rect, or an index and a frame width", name)
+			}
+
+			if !rectWithin(frame.rect, sheetBounds) {
+				return nil, fmt.Errorf("frame in %s is outside the sprite sheet", name)
+			}
+
+			clip.frames = append(clip.frames, frame)
+		}
+
+		if len(clip.frames) == 0 {
+			return nil, fmt.Errorf("%s has no frames", name)
+		}
+
+		clips[name] = clip
+	}
+
+	return clips, nil
+}
+
+// Flip from image coordinates to the sheet's, which start from the bottom left.
+func (rect *animationFileRect) sheetRect(sheetBounds pixel.Rect) pixel.Rect {
+	return pixel.R(rect.X, sheetBounds.Max.Y-rect.Y-rect.H, rect.X+rect.W, sheetBounds.Max.Y-rect.Y)
+}
+
+func rectWithin(rect, bounds pixel.Rect) bool {
+	return rect.Min.X >= bounds.Min.X && rect.Max.X <= bounds.Max.X && rect.Min.Y >= bounds.Min.Y && rect.Max.Y <= bounds.Max.Y
+}
+
+// Equally timed, looping clips from lists of frames (as read from the CSV mappings).
+func clipsFromFrames(anims map[string][]pixel.Rect, frameDuration float64) map[string]*animationClip {
+	clips := map[string]*animationClip{}
+	for name, rects := range anims {
+		clip := &animationClip{mode: loopPlayback}
+		for _, rect := range rects {
+			clip.frames = append(clip.frames, animationFrame{rect: rect, duration: frameDuration})
+		}
+
+		clips[name] = clip
+	}
+
+	return clips
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/faiface/pixel"
+)
+
+// A clip of frames lasting the given durations, in the given mode.
+func testClip(mode playbackMode, durations ...float64) *animationClip {
+	clip := &animationClip{mode: mode}
+	for _, duration := range durations {
+		clip.frames = append(clip.frames, animationFrame{duration: duration})
+	}
+
+	return clip
+}
+
+func TestPlaybackOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		clip *animationClip
+		want []int
+	}{
+		{"loop", testClip(loopPlayback, .1, .1, .1), []int{0, 1, 2}},
+		{"once", testClip(oncePlayback, .1, .1, .1), []int{0, 1, 2}},
+		{"ping-pong", testClip(pingPongPlayback, .1, .1, .1, .1), []int{0, 1, 2, 3, 2, 1}},
+		{"ping-pong of two frames", testClip(pingPongPlayback, .1, .1), []int{0, 1}},
+		{"single frame", testClip(pingPongPlayback, .1), []int{0}},
+	}
+
+	for _, test := range tests {
+		if got := test.clip.playbackOrder(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: playback order %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFrameAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		clip    *animationClip
+		counter float64
+		want    int
+	}{
+		{"loop start", testClip(loopPlayback, .1, .2, .3), 0, 0},
+		{"loop second frame", testClip(loopPlayback, .1, .2, .3), .15, 1},
+		{"loop last frame", testClip(loopPlayback, .1, .2, .3), .45, 2},
+		{"loop wraps around", testClip(loopPlayback, .1, .2, .3), .65, 0},
+		{"once holds the last frame", testClip(oncePlayback, .1, .2, .3), 5, 2},
+		{"ping-pong on the way there", testClip(pingPongPlayback, .1, .1, .1), .25, 2},
+		{"ping-pong on the way back", testClip(pingPongPlayback, .1, .1, .1), .35, 1},
+		{"ping-pong wraps around", testClip(pingPongPlayback, .1, .1, .1), .45, 0},
+		{"no frames", testClip(loopPlayback), 1, 0},
+		{"no length", testClip(loopPlayback, 0, 0), 1, 0},
+	}
+
+	for _, test := range tests {
+		if got := test.clip.frameAt(test.counter); got != test.want {
+			t.Errorf("%s: frame %d at %v, want %d", test.name, got, test.counter, test.want)
+		}
+	}
+}
+
+// The shared defaults have landing, takeoff and idle clips for every species to play, on any of the standard sheets.
+func TestDefaultAnimations(t *testing.T) {
+	contents, err := ioutil.ReadFile(defaultAnimationFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sheetBounds := pixel.R(0, 0, 8*standardSpriteWidth, standardSpriteWidth)
+	clips, err := parseAnimationFile([][]byte{contents}, sheetBounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Perch", "Eat", "Sing", "Fly", "Land", "TakeOff"} {
+		if _, found := clips[name]; !found {
+			t.Errorf("no %s clip", name)
+		}
+	}
+
+	idleClips := 0
+	for name := range idleClipLikelihoods {
+		if _, found := clips[name]; found {
+			idleClips++
+		}
+	}
+	if idleClips == 0 {
+		t.Error("no idle clips")
+	}
+
+	for _, name := range []string{"Land", "TakeOff"} {
+		if clip := clips[name]; clip != nil && clip.mode != oncePlayback {
+			t.Errorf("%s clip doesn't play once", name)
+		}
+	}
+}
@@ -6,9 +6,7 @@ import (
 	"math"
 	"time"
 
-	"github.com/faiface/beep"
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/imdraw"
 	wr "github.com/mroth/weightedrand"
 )
 
@@ -30,16 +28,25 @@ type bird struct {
 	eating   bool
 	removed  bool
 	singing  bool
+	waiting  bool
 
 	perch      *perch
 	exitTarget pixel.Rect
 
-	songControl *beep.Ctrl
+	// The flock this bird arrived with, if any.
+	flock *flock
+
+	// The song (or call) currently playing, positioned wherever the bird is.
+	song        *positionedSound
 	doneSinging chan bool
 
 	// Birds will only be set to sing sometimes, regardless of the scheduled time.
 	chosenToSing bool
 
+	// Who the next song answers, if anyone, and how far into a bout of countersinging it is.
+	answering      singer
+	answerExchange int
+
 	xPerchDistance float64
 	yPerchDistance float64
 
@@ -50,7 +57,7 @@ type bird struct {
 	totalExitMoves  int
 }
 
-func newBird(frameRate float64, species species, birdAnimations map[string][]pixel.Rect, animationSheet pixel.Picture, perch *perch, imd *imdraw.IMDraw) *bird {
+func newBird(species species, birdAnimations map[string]*animationClip, perch *perch) *bird {
 	// Resolve spawn location and exit target location.
 	spawnLocation, err := getOutsideLocation(species)
 	exitTarget, err := getOutsideLocation(species)
@@ -66,13 +73,10 @@ func newBird(frameRate float64, species species, birdAnimations map[string][]pix
 	}
 
 	anim := &birdAnimation{
-		sheet: animationSheet,
-		anims: birdAnimations,
-		rate:  frameRate,
-		imd:   imd,
+		clips: birdAnimations,
 	}
 
-	newBird := &bird{species: species, entranceTime: time.Now(), physics: phys, animation: anim, perch: perch, exitTarget: exitTarget, doneSinging: make(chan bool)}
+	newBird := &bird{species: species, entranceTime: time.Now(), physics: phys, animation: anim, perch: perch, exitTarget: exitTarget, doneSinging: make(chan bool, 1)}
 
 	// Bird should be set to 'entering' status.
 	newBird.setEntranceStatus()
@@ -83,14 +87,24 @@ func newBird(frameRate float64, species species, birdAnimations map[string][]pix
 	// Set the next time the bird should eat.
 	newBird.setEatingStartTime()
 
-	// Set properties for entrance and exit moves.
+	// Set properties for entrance moves. Exit moves are set once the bird actually leaves.
 	newBird.initEntryMoves()
-	newBird.initExitMoves()
+
+	// Birds that couldn't fit on the feeder queue up for it.
+	if perch.waiting {
+		enqueueWaitingBird(newBird)
+	}
+
+	// Announce the arrival, now and then.
+	newBird.maybeCall()
 
 	return newBird
 }
 
 func (bird *bird) update(elapsed float64) {
+	// Keep any song following the bird around.
+	bird.updateSongPosition()
+
 	if bird.exiting || bird.entering {
 		// Move the flying bird accordingly.
 		nextX, nextY, adjustForFlightSpeed := bird.getNextFlightMove()
@@ -130,7 +144,7 @@ func (bird *bird) update(elapsed float64) {
 			} else {
 				// If we've reached the next set singing time, have the bird start singing.
 				bird.setSingingStatus()
-				go playSound(soundBuffers[bird.species.Song()], bird.doneSinging)
+				bird.sing()
 			}
 		} else if time.Now().After(bird.eatingStartTime) {
 			// Bird can't eat if seed is finished. Eat later.
@@ -177,6 +191,7 @@ func (bird *bird) setRemovedStatus() {
 	bird.singing = false
 	bird.perched = false
 	bird.removed = true
+	bird.waiting = false
 }
 
 func (bird *bird) setExitStatus() {
@@ -188,7 +203,35 @@ func (bird *bird) setExitStatus() {
 	bird.singing = false
 	bird.perched = false
 	bird.removed = false
+	bird.waiting = false
 	bird.perch.occupied = false
+
+	// Exit moves start from wherever the bird is now.
+	bird.initExitMoves()
+	bird.maybeCall()
+
+	// A bird giving up on waiting leaves the queue. A bird leaving the feeder makes room for the next in line.
+	if bird.perch.waiting {
+		dequeueWaitingBird(bird)
+	} else {
+		promoteWaitingBird(bird.perch)
+	}
+}
+
+// Like exiting, but the perch is left occupied since whoever chased this bird off has already claimed it.
+func (bird *bird) setFleeingStatus() {
+	bird.exiting = true
+	bird.entering = false
+	bird.eating = false
+	bird.singing = false
+	bird.perched = false
+	bird.removed = false
+	bird.waiting = false
+
+	// Get out of there in a hurry.
+	bird.physics.flightSpeed = bird.species.FlightSpeed() * fleeingSpeedMultiplier
+	bird.initExitMoves()
+	bird.maybeCall()
 }
 
 func (bird *bird) setPerchedStatus() {
@@ -200,6 +243,7 @@ func (bird *bird) setPerchedStatus() {
 	bird.singing = false
 	bird.perched = true
 	bird.removed = false
+	bird.waiting = false
 	bird.perch.occupied = true
 }
 
@@ -210,6 +254,7 @@ func (bird *bird) setEatingStatus() {
 	bird.singing = false
 	bird.perched = true
 	bird.removed = false
+	bird.waiting = false
 	bird.perch.occupied = true
 }
 
@@ -221,6 +266,7 @@ func (bird *bird) setEntranceStatus() {
 	bird.singing = false
 	bird.perched = false
 	bird.removed = false
+	bird.waiting = false
 	bird.perch.occupied = true
 }
 
@@ -231,6 +277,18 @@ func (bird *bird) setSingingStatus() {
 	bird.singing = true
 	bird.perched = true
 	bird.removed = false
+	bird.waiting = false
+	bird.perch.occupied = true
+}
+
+func (bird *bird) setWaitingStatus() {
+	bird.exiting = false
+	bird.entering = false
+	bird.eating = false
+	bird.singing = false
+	bird.perched = false
+	bird.removed = false
+	bird.waiting = true
 	bird.perch.occupied = true
 }
 
@@ -273,33 +331,36 @@ func resolveNewLengthInSeconds(timeMap map[timeLength]pair) (int, error) {
 }
 
 func getOutsideLocation(species species) (pixel.Rect, error) {
+	// Just out of sight, however much of the scene the window shows.
+	visible := layout.visibleBounds
+
 	// Randomly choose from one of four 'areas' to find a location.
 	random := nextRandomInt(0, 3)
 
 	switch random {
 	case 0:
 		// Left entry.
-		maxMinXLocation := -(winWidth / 2) - species.Width()
+		maxMinXLocation := visible.Min.X - species.Width()
 		leftMinX := maxMinXLocation
-		leftMinY := nextRandomFloat64(-(winHeight/2)-spawnRandomnessOffset, (winHeight/2)+spawnRandomnessOffset)
+		leftMinY := nextRandomFloat64(visible.Min.Y-spawnRandomnessOffset, visible.Max.Y+spawnRandomnessOffset)
 		return pixel.R(leftMinX, leftMinY, leftMinX+species.Width(), leftMinY+species.Height()), nil
 	case 1:
 		// Right entry.
-		minMaxXLocation := (winWidth / 2) + species.Width()
+		minMaxXLocation := visible.Max.X + species.Width()
 		rightMaxX := minMaxXLocation
-		rightMinY := nextRandomFloat64(-(winHeight/2)-spawnRandomnessOffset, (winHeight/2)+spawnRandomnessOffset)
+		rightMinY := nextRandomFloat64(visible.Min.Y-spawnRandomnessOffset, visible.Max.Y+spawnRandomnessOffset)
 		return pixel.R(rightMaxX-species.Width(), rightMinY, rightMaxX, rightMinY+species.Height()), nil
 	case 2:
 		// Top entry.
-		minMaxYLocation := (winHeight / 2) + species.Height()
+		minMaxYLocation := visible.Max.Y + species.Height()
 		topMaxY := minMaxYLocation
-		topMinX := nextRandomFloat64(-(winWidth/2)-spawnRandomnessOffset, (winWidth/2)+spawnRandomnessOffset)
+		topMinX := nextRandomFloat64(visible.Min.X-spawnRandomnessOffset, visible.Max.X+spawnRandomnessOffset)
 		return pixel.R(topMinX, topMaxY-species.Height(), topMinX+species.Width(), topMaxY), nil
 	case 3:
 		// Bottom entry.
-		maxMinYLocation := -(winHeight / 2) - species.Height()
+		maxMinYLocation := visible.Min.Y - species.Height()
 		bottomMinY := maxMinYLocation
-		bottomMinX := nextRandomFloat64(-(winWidth/2)-spawnRandomnessOffset, (winWidth/2)+spawnRandomnessOffset)
+		bottomMinX := nextRandomFloat64(visible.Min.X-spawnRandomnessOffset, visible.Max.X+spawnRandomnessOffset)
 		return pixel.R(bottomMinX, bottomMinY, bottomMinX+species.Width(), bottomMinY+species.Height()), nil
 	}
 
@@ -312,7 +373,8 @@ func (bird *bird) setRemovalTime() {
 		feederLength = defaultFeederLength
 	}
 
-	bird.removalTime = time.Now().Add(time.Second * time.Duration(feederLength))
+	// A birdbath keeps them around a little longer.
+	bird.removalTime = time.Now().Add(time.Duration(float64(time.Second) * float64(feederLength) * economy.visitLengthMultiplier()))
 }
 
 func (bird *bird) setEatingStartTime() {
@@ -330,27 +392,74 @@ func (bird *bird) setEatingEndTime() {
 		eatingLength = defaultEatingLength
 	}
 
+	// Birds eat for longer or shorter depending on the weather.
+	eatingLength = eatingLength * int(weather.effect().eatingPercent) / 100
+
 	bird.eatingEndTime = time.Now().Add(time.Second * time.Duration(eatingLength))
 }
 
+// Put back everything the bird has planned, after the scene has stood still for the given length.
+func (bird *bird) postpone(length time.Duration) {
+	bird.entranceTime = postponed(bird.entranceTime, length)
+	bird.removalTime = postponed(bird.removalTime, length)
+	bird.eatingStartTime = postponed(bird.eatingStartTime, length)
+	bird.eatingEndTime = postponed(bird.eatingEndTime, length)
+	bird.singingStartTime = postponed(bird.singingStartTime, length)
+}
+
 func (bird *bird) setSingingStartTime() {
+	bird.singingStartTime, bird.chosenToSing = resolveNextSong(bird.species)
+	bird.answering = nil
+	bird.answerExchange = 0
+}
+
+// When a bird of the given species next gets the chance to sing, and whether it will.
+func resolveNextSong(species species) (time.Time, bool) {
 	singingGap, err := resolveNewLengthInSeconds(context.SingingGapRanges())
 	if err != nil {
 		singingGap = defaultSingingGap
 	}
 
-	bird.singingStartTime = time.Now().Add(time.Second * time.Duration(singingGap))
+	// Songs come in quicker succession during a chorus, and further apart in the midday heat.
+	singingGap = singingGap * dayPhaseSingingGapPercents[getDayPhase(time.Now())] / 100
+
+	// Birds sing less in bad weather, and more or less depending on the time of day and year.
+	singingLikelihood := species.SingingLikelihood() * weather.effect().singingPercent / 100 * singingPercent(time.Now()) / 100
+	if singingLikelihood > likelihoodMaxPercent {
+		singingLikelihood = likelihoodMaxPercent
+	}
+
+	// Species without a recording of their own song stay quiet, rather than singing another species' song.
+	if !hasSong(species) {
+		singingLikelihood = 0
+	}
 
 	// Create two choices: true to sing, false to not sing.
 	choices := []wr.Choice{}
-	choices = append(choices, wr.Choice{Item: true, Weight: bird.species.SingingLikelihood()})
-	choices = append(choices, wr.Choice{Item: false, Weight: likelihoodMaxPercent - bird.species.SingingLikelihood()})
+	choices = append(choices, wr.Choice{Item: true, Weight: singingLikelihood})
+	choices = append(choices, wr.Choice{Item: false, Weight: likelihoodMaxPercent - singingLikelihood})
 
 	// Initialize a weighted probability boolean chooser.
 	chooser, _ := wr.NewChooser(choices...)
 
 	// Pick a random to-sing-or-not-to-sing result.
-	bird.chosenToSing = chooser.Pick().(bool)
+	return time.Now().Add(time.Second * time.Duration(singingGap)), chooser.Pick().(bool)
+}
+
+func hasSong(species species) bool {
+	_, found := soundBuffers[species.Song()]
+	return found
+}
+
+// Let others of the same species hear the song, so they can answer it.
+func (bird *bird) sing() {
+	buffer := soundBuffers[bird.species.Song()]
+	songs.started(bird, bird.answerExchange, bird.answering, buffer)
+	bird.answering = nil
+	bird.answerExchange = 0
+
+	announceSong(bird, buffer)
+	bird.playSong(buffer, bird.doneSinging)
 }
 
 func (bird *bird) stopSinging() {
@@ -364,8 +473,12 @@ func (bird *bird) nextEntryMove(targetMinY, targetMaxX float64) (float64, float6
 	currentMaxX := bird.physics.rect.Max.X
 
 	if currentMinY == targetMinY && currentMaxX == targetMaxX {
-		// If the bird has finished its entry moves, set it as 'perched' and end movement.
-		bird.setPerchedStatus()
+		// If the bird has finished its entry moves, set it as 'perched' (or 'waiting' off the feeder) and end movement.
+		if bird.perch.waiting {
+			bird.setWaitingStatus()
+		} else {
+			bird.setPerchedStatus()
+		}
 
 		// Allow directional changes now that we're perched.
 		bird.animation.UnlockDirection()
@@ -378,8 +491,14 @@ func (bird *bird) nextEntryMove(targetMinY, targetMaxX float64) (float64, float6
 	xDifference := targetMaxX - currentMaxX
 	yDifference := targetMinY - currentMinY
 
+	// Flock members don't fly in a straight line, so only their distance to the perch matters.
+	finalMove := math.Abs(xDifference) <= math.Abs(xMove) && math.Abs(yDifference) <= math.Abs(yMove)
+	if bird.flock != nil {
+		finalMove = math.Hypot(xDifference, yDifference) <= math.Hypot(xMove, yMove)
+	}
+
 	adjustForFlightSpeed := true
-	if math.Abs(xDifference) <= math.Abs(xMove) && math.Abs(yDifference) <= math.Abs(yMove) {
+	if finalMove {
 		adjustForFlightSpeed = false
 
 		xMove = xDifference
@@ -387,6 +506,8 @@ func (bird *bird) nextEntryMove(targetMinY, targetMaxX float64) (float64, float6
 
 		// Forcing the move direction into place may alter direction abruptly. We prevent this here.
 		bird.animation.LockDirection()
+	} else if bird.flock != nil {
+		xMove, yMove = bird.nextFlockMove(xDifference, yDifference, math.Hypot(xMove, yMove))
 	}
 
 	return xMove, yMove, adjustForFlightSpeed
@@ -436,7 +557,28 @@ func (bird *bird) initExitMoves() {
 	exitTargetMinY := bird.exitTarget.Min.Y
 	exitTargetMaxX := bird.exitTarget.Max.X - exitEmptySpaceOnBothSides
 
-	bird.yExitDistance = exitTargetMinY - bird.perch.Y.min
-	bird.xExitDistance = exitTargetMaxX - bird.perch.X.max
+	bird.yExitDistance = exitTargetMinY - bird.physics.rect.Min.Y
+	bird.xExitDistance = exitTargetMaxX - bird.physics.rect.Max.X
 	bird.totalExitMoves = int(math.Round(math.Max(math.Abs(bird.xExitDistance), math.Abs(bird.yExitDistance)) / 10))
 }
+
+func (bird *bird) flyToPerch(newPerch *perch) {
+	// Give up the current perch and head for the new one.
+	bird.perch.occupied = false
+	bird.headForPerch(newPerch)
+}
+
+func (bird *bird) headForPerch(newPerch *perch) {
+	bird.perch = newPerch
+
+	// Birds heading for a waiting perch join the queue for the feeder straight away.
+	if newPerch.waiting {
+		enqueueWaitingBird(bird)
+	}
+	bird.initEntryMoves()
+	bird.setEntranceStatus()
+
+	// The visit starts over once the bird makes it to the new perch.
+	bird.setRemovalTime()
+	bird.setEatingStartTime()
+}
@@ -1,26 +1,44 @@
 package main
 
 import (
-	"math"
-
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/imdraw"
+	wr "github.com/mroth/weightedrand"
 )
 
+// Clips a perched bird plays now and then, by how likely each is to be picked. A species without any of them simply
+// sits still.
+var idleClipLikelihoods = map[string]uint{
+	"HeadTurn":   300,
+	"LookAround": 250,
+	"TailFlick":  200,
+	"Preen":      150,
+	"Fluff":      100,
+}
+
 type birdAnimation struct {
-	sheet pixel.Picture
-	anims map[string][]pixel.Rect
-	rate  float64
+	clips map[string]*animationClip
+
+	// The clip playing, and which of its frames is showing.
+	clip       *animationClip
+	frameIndex int
 
 	state         animState
 	counter       float64
 	direction     float64
 	lockDirection bool
 
-	frame pixel.Rect
+	// A clip played through once over the top of the state's own, e.g. landing or an idle clip, and how far into it.
+	oneShot        *animationClip
+	oneShotCounter float64
+
+	// When (by the counter) a perched bird next does something idle.
+	nextIdleTime float64
+
+	started bool
+
+	frame animationFrame
 
 	sprite *pixel.Sprite
-	imd    *imdraw.IMDraw
 }
 
 func (animation *birdAnimation) LockDirection() {
@@ -41,31 +59,73 @@ func (animation *birdAnimation) update(elapsed float64, bird *bird) {
 		newState = singing
 	case bird.eating:
 		newState = eating
-	case bird.perched:
+	case bird.perched || bird.waiting:
 		newState = perched
 	case bird.entering || bird.exiting:
 		newState = flying
 	}
 
-	// reset the time counter if the state changed
-	if animation.state != newState {
+	// reset the time counter if the state changed, landing or taking off on the way between flying and perching
+	if animation.state != newState || !animation.started {
+		previousState := animation.state
+
 		animation.state = newState
 		animation.counter = 0
+		animation.oneShot = nil
+		animation.scheduleIdle()
+
+		if animation.started && previousState == flying {
+			animation.playOnce("Land")
+		} else if animation.started && newState == flying {
+			animation.playOnce("TakeOff")
+		}
+
+		animation.started = true
+	}
+
+	// do something idle now and then while perched
+	if animation.state == perched && animation.oneShot == nil && animation.counter >= animation.nextIdleTime {
+		animation.playIdle()
+		animation.scheduleIdle()
 	}
 
-	// determine the correct animation frame
+	// determine the correct animation clip, going back to the state's own once a one-off clip has played through
+	var clip *animationClip
 	switch animation.state {
 	case perched:
-		animation.frame = animation.anims["Perch"][0]
+		clip = animation.clips["Perch"]
 	case flying:
-		i := int(math.Floor(animation.counter / animation.rate))
-		animation.frame = animation.anims["Fly"][i%len(animation.anims["Fly"])]
+		clip = animation.clips["Fly"]
 	case eating:
-		i := int(math.Floor(animation.counter / animation.rate))
-		animation.frame = animation.anims["Eat"][i%len(animation.anims["Eat"])]
+		clip = animation.clips["Eat"]
 	case singing:
-		i := int(math.Floor(animation.counter / animation.rate))
-		animation.frame = animation.anims["Sing"][i%len(animation.anims["Sing"])]
+		clip = animation.clips["Sing"]
+	}
+
+	counter := animation.counter
+	if animation.oneShot != nil {
+		animation.oneShotCounter += elapsed
+
+		if animation.oneShotCounter < animation.oneShot.length() {
+			clip = animation.oneShot
+			counter = animation.oneShotCounter
+		} else {
+			animation.oneShot = nil
+		}
+	}
+
+	// ...and the frame of it to show, letting the bird know about any events as their frames come up
+	if clip != nil && len(clip.frames) > 0 {
+		frameIndex := clip.frameAt(counter)
+		if clip != animation.clip || frameIndex != animation.frameIndex {
+			for _, event := range clip.frames[frameIndex].events {
+				bird.onAnimationEvent(event)
+			}
+		}
+
+		animation.clip = clip
+		animation.frameIndex = frameIndex
+		animation.frame = clip.frames[frameIndex]
 	}
 
 	// set the facing direction of the bird
@@ -78,16 +138,48 @@ func (animation *birdAnimation) update(elapsed float64, bird *bird) {
 	}
 }
 
-func (animation *birdAnimation) draw(phys *birdPhysics) {
+// Play the named clip through once, if the species has it.
+func (animation *birdAnimation) playOnce(name string) {
+	if clip, found := animation.clips[name]; found && len(clip.frames) > 0 {
+		animation.oneShot = clip
+		animation.oneShotCounter = 0
+	}
+}
+
+// Play one of the idle clips the species has, picked at random.
+func (animation *birdAnimation) playIdle() {
+	choices := []wr.Choice{}
+	for name, likelihood := range idleClipLikelihoods {
+		if _, found := animation.clips[name]; found {
+			choices = append(choices, wr.Choice{Item: name, Weight: likelihood})
+		}
+	}
+
+	if len(choices) == 0 {
+		return
+	}
+
+	chooser, _ := wr.NewChooser(choices...)
+	animation.playOnce(chooser.Pick().(string))
+}
+
+func (animation *birdAnimation) scheduleIdle() {
+	animation.nextIdleTime = animation.counter + float64(nextRandomInt(idleGapRange.min, idleGapRange.max))
+}
+
+// Draw the frame showing into the target, which takes pictures from the sheet (i.e. the atlas batch).
+func (animation *birdAnimation) draw(phys *birdPhysics, target pixel.Target, sheet pixel.Picture) {
 	if animation.sprite == nil {
 		animation.sprite = pixel.NewSprite(nil, pixel.Rect{})
 	}
-	// draw the correct frame with the correct position and direction
-	animation.sprite.Set(animation.sheet, animation.frame)
-	animation.sprite.Draw(animation.imd, pixel.IM.
+	// draw the correct frame with the correct position and direction, putting trimmed frames back where they were cut from
+	size := animation.frame.sourceSize()
+	animation.sprite.Set(sheet, animation.frame.rect)
+	animation.sprite.Draw(target, pixel.IM.
+		Moved(animation.frame.trimOffset).
 		ScaledXY(pixel.ZV, pixel.V(
-			phys.rect.W()/animation.sprite.Frame().W(),
-			phys.rect.H()/animation.sprite.Frame().H(),
+			phys.rect.W()/size.X,
+			phys.rect.H()/size.Y,
 		)).
 		ScaledXY(pixel.ZV, pixel.V(-animation.direction, 1)).
 		Moved(phys.rect.Center()),
@@ -26,6 +26,8 @@ type birdSeed struct {
 
 	// The Y coordinate to reach (progressing from low to high) for the seed to be 'done'.
 	doneLowerY float64
+
+	sprite *pixel.Sprite
 }
 
 func (seed *birdSeed) getRemainingSeedCount(birds []*bird) float64 {
@@ -57,6 +59,10 @@ func (seed *birdSeed) draw(imd *imdraw.IMDraw, birds []*bird, picture pixel.Pict
 
 	// Ensure bird can be aware when seed is finished.
 	if lowerY >= seed.doneLowerY {
+		if !seed.Finished {
+			simulationEvents.publish(simulationEvent{kind: feederEmptied})
+		}
+
 		seed.Finished = true
 	} else {
 		seed.Finished = false
@@ -68,21 +74,28 @@ func (seed *birdSeed) draw(imd *imdraw.IMDraw, birds []*bird, picture pixel.Pict
 	// Bottom left point.
 	minVec := pixel.Vec{X: seed.center.X - seed.width/2, Y: lowerY}
 
-	seedSprite := pixel.NewSprite(nil, pixel.Rect{})
-	seedSprite.Set(picture, pixel.Rect{Min: minVec, Max: maxVec})
+	if seed.sprite == nil {
+		seed.sprite = pixel.NewSprite(nil, pixel.Rect{})
+	}
+	seed.sprite.Set(picture, pixel.Rect{Min: minVec, Max: maxVec})
 
 	// Draw the seeds sprite.
-	seedSprite.Draw(imd, pixel.IM.ScaledXY(pixel.ZV, pixel.V(seed.scaleX, seed.scaleY)).Moved(pixel.Vec{X: seed.adjustedX, Y: seed.adjustedY}))
+	seed.sprite.Draw(imd, pixel.IM.ScaledXY(pixel.ZV, pixel.V(seed.scaleX, seed.scaleY)).Moved(pixel.Vec{X: seed.adjustedX, Y: seed.adjustedY}))
 }
 
-func (seed *birdSeed) refill() {
-	incrementAmount := seed.originalSeedCount * defaultSeedRefillMultiplier
-
-	if seed.seedCount+incrementAmount > seed.originalSeedCount {
-		incrementAmount = seed.originalSeedCount - seed.seedCount
+// Pour in up to the given amount of seed, returning how much actually fit.
+func (seed *birdSeed) add(amount float64) float64 {
+	if seed.seedCount+amount > seed.originalSeedCount {
+		amount = seed.originalSeedCount - seed.seedCount
 	}
 
-	seed.seedCount += incrementAmount
+	seed.seedCount += amount
+	return amount
+}
+
+// Fill the feeder right back up.
+func (seed *birdSeed) fill() {
+	seed.seedCount = seed.originalSeedCount
 }
 
 func newBirdSeed(center pixel.Vec, height, width, seedCount, scaleX, scaleY, adjustedX, adjustedY, doneLowerY float64) *birdSeed {
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// Whether songs are captioned on screen, and whether the song being played is drawn in a panel.
+var captionsEnabled bool
+var songVisualisationEnabled bool
+
+// A line of text saying who is singing and what, e.g. "♪ Northern Cardinal: cheer-cheer-cheer".
+type caption struct {
+	text string
+
+	// The caption follows the singer around, if there is one. Otherwise it stays put.
+	singer   singer
+	position pixel.Vec
+
+	endTime time.Time
+}
+
+// The captions currently on screen.
+type captionBoard struct {
+	captions []*caption
+	imd      *imdraw.IMDraw
+	text     *text.Text
+}
+
+var captions = &captionBoard{}
+
+// Caption a song and show it in the visualisation panel, as the singer starts it.
+func announceSong(singer singer, buffer *beep.Buffer) {
+	species := singer.singerSpecies()
+
+	captions.add(species.Name(), species.SongMnemonic(), singer, singer.singerPosition(), soundLength(buffer))
+	visualiser.follow(species.Name(), buffer)
+}
+
+// Caption a call, which has a caption of its own but shows in the panel just like a song.
+func announceCall(singer singer, buffer *beep.Buffer) {
+	species := singer.singerSpecies()
+
+	captions.add(species.Name(), species.CallMnemonic(), singer, singer.singerPosition(), soundLength(buffer))
+	visualiser.follow(species.Name(), buffer)
+}
+
+func (board *captionBoard) add(name, mnemonic string, singer singer, position pixel.Vec, length time.Duration) {
+	if !captionsEnabled {
+		return
+	}
+
+	// Whoever's singing already has a caption, so replace it rather than stacking another on top.
+	board.remove(singer)
+
+	line := name
+	if mnemonic != "" {
+		line += ": " + mnemonic
+	}
+
+	board.captions = append(board.captions, &caption{
+		text:     line,
+		singer:   singer,
+		position: position,
+		endTime:  time.Now().Add(time.Duration(math.Max(float64(length), float64(minimumCaptionLength)))),
+	})
+}
+
+func (board *captionBoard) remove(singer singer) {
+	if singer == nil {
+		return
+	}
+
+	remaining := []*caption{}
+	for _, caption := range board.captions {
+		if caption.singer != singer {
+			remaining = append(remaining, caption)
+		}
+	}
+	board.captions = remaining
+}
+
+func (board *captionBoard) update() {
+	remaining := []*caption{}
+	for _, caption := range board.captions {
+		if time.Now().Before(caption.endTime) && captionsEnabled {
+			remaining = append(remaining, caption)
+		}
+	}
+	board.captions = remaining
+}
+
+// Draw each caption just above its singer. Anyone singing out of sight is captioned at the nearest edge of the screen.
+func (board *captionBoard) draw(target pixel.Target) {
+	if len(board.captions) == 0 {
+		return
+	}
+
+	if board.text == nil {
+		board.imd = imdraw.New(nil)
+		board.text = text.New(pixel.ZV, text.NewAtlas(basicfont.Face7x13, text.ASCII))
+	}
+	imd := board.imd
+	imd.Clear()
+	board.text.Clear()
+	board.text.Color = color.White
+
+	for _, caption := range board.captions {
+		anchor := caption.position
+		if caption.singer != nil {
+			anchor = caption.singer.singerPosition()
+			if bird, isBird := caption.singer.(*bird); isBird {
+				anchor.Y += bird.species.Height() / 2
+			}
+		}
+
+		// The note, then the text beside it, on a dark backing so it reads over anything.
+		textWidth := board.text.BoundsOf(caption.text).W() * captionTextScale
+		textHeight := board.text.Atlas().LineHeight() * captionTextScale
+		width := captionNoteWidth + textWidth + captionPadding*3
+		height := textHeight + captionPadding*2
+
+		min := clampToScreen(pixel.V(anchor.X-width/2, anchor.Y+captionPadding), width, height)
+
+		imd.Color = color.RGBA{A: 170}
+		imd.Push(min, min.Add(pixel.V(width, height)))
+		imd.Rectangle(0)
+
+		drawNoteGlyph(imd, min.Add(pixel.V(captionPadding, captionPadding)), textHeight)
+
+		board.text.Dot = min.Add(pixel.V(captionPadding*2+captionNoteWidth, captionPadding+textHeight/4)).Scaled(1 / captionTextScale)
+		board.text.WriteString(caption.text)
+	}
+
+	imd.Draw(target)
+	board.text.Draw(target, pixel.IM.Scaled(pixel.ZV, captionTextScale))
+}
+
+// Keep a box of the given size inside the part of the scene on screen.
+func clampToScreen(min pixel.Vec, width, height float64) pixel.Vec {
+	visible := layout.visibleBounds
+	return pixel.V(
+		math.Max(visible.Min.X+captionPadding, math.Min(visible.Max.X-captionPadding-width, min.X)),
+		math.Max(visible.Min.Y+captionPadding, math.Min(visible.Max.Y-captionPadding-height, min.Y)),
+	)
+}
+
+// The built-in font has no musical note, so draw one: a head, a stem and a flag.
+func drawNoteGlyph(imd *imdraw.IMDraw, min pixel.Vec, height float64) {
+	headRadius := pixel.V(captionNoteWidth/3, captionNoteWidth/4)
+	head := min.Add(pixel.V(headRadius.X, headRadius.Y))
+	stemBottom := head.Add(pixel.V(headRadius.X*.9, 0))
+	stemTop := stemBottom.Add(pixel.V(0, height-headRadius.Y))
+
+	imd.Color = color.White
+	imd.Push(head)
+	imd.Ellipse(headRadius, 0)
+
+	imd.Push(stemBottom, stemTop)
+	imd.Line(2)
+
+	imd.Push(stemTop, stemTop.Add(pixel.V(captionNoteWidth/3, -height/3)))
+	imd.Line(2)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"time"
+
+	"github.com/faiface/pixel"
+	wr "github.com/mroth/weightedrand"
+)
+
+type dayPhase int
+
+const (
+	night dayPhase = iota
+	dawn
+	morning
+	midday
+	afternoon
+	evening
+)
+
+// Percentage of a bird's usual singing likelihood, by phase of the day. Birds sing hardest in the dawn chorus, go
+// nearly quiet in the midday heat, and pick up again for a smaller evening chorus.
+var dayPhaseSingingPercents = map[dayPhase]uint{
+	night:     0,
+	dawn:      400,
+	morning:   150,
+	midday:    10,
+	afternoon: 60,
+	evening:   200,
+}
+
+// Percentage of the usual gap between songs, by phase of the day.
+var dayPhaseSingingGapPercents = map[dayPhase]int{
+	night:     100,
+	dawn:      20,
+	morning:   60,
+	midday:    300,
+	afternoon: 120,
+	evening:   40,
+}
+
+// How many birds sing out of sight at once, by phase of the day (before the season has its say).
+var dayPhaseOffscreenSingers = map[dayPhase]int{
+	night:     0,
+	dawn:      8,
+	morning:   3,
+	midday:    0,
+	afternoon: 1,
+	evening:   4,
+}
+
+// Percentage of a bird's usual singing likelihood, by season. Spring is for singing.
+var seasonSingingPercents = map[season]uint{
+	winter: 30,
+	spring: 150,
+	summer: 100,
+	autumn: 50,
+}
+
+func getDayPhase(date time.Time) dayPhase {
+	hour := float64(date.Hour()) + float64(date.Minute())/60
+
+	switch {
+	case hour < defaultSunriseHour-1 || hour >= defaultSunsetHour+.5:
+		return night
+	case hour < defaultSunriseHour+1.5:
+		return dawn
+	case hour < 11:
+		return morning
+	case hour < 15:
+		return midday
+	case hour < defaultSunsetHour-1.5:
+		return afternoon
+	default:
+		return evening
+	}
+}
+
+// Percentage of a bird's usual singing likelihood on the given date, for the time of day and year.
+func singingPercent(date time.Time) uint {
+	return dayPhaseSingingPercents[getDayPhase(date)] * seasonSingingPercents[getSeason(date)] / 100
+}
+
+// A bird singing somewhere out of sight. It's heard, but never seen.
+type offscreenSinger struct {
+	species  species
+	position pixel.Vec
+
+	leaveTime        time.Time
+	singingStartTime time.Time
+
+	singing      bool
+	chosenToSing bool
+
+	// Who the next song answers, if anyone, and how far into a bout of countersinging it is.
+	answering      singer
+	answerExchange int
+
+	song        *positionedSound
+	doneSinging chan bool
+}
+
+func newOffscreenSinger(species species) *offscreenSinger {
+	// Somewhere off to the left or right of the yard, more often up in the trees than down on the ground.
+	distance := winWidth/2 + nextRandomFloat64(offscreenSingerMinDistance, offscreenSingerMaxDistance)
+	if nextRandomInt(0, 2) == 0 {
+		distance = -distance
+	}
+
+	singer := &offscreenSinger{
+		species:     species,
+		position:    pixel.V(distance, nextRandomFloat64(-winHeight/2, winHeight)),
+		leaveTime:   time.Now().Add(time.Second * time.Duration(nextRandomInt(offscreenSingerStayRange.min, offscreenSingerStayRange.max))),
+		doneSinging: make(chan bool, 1),
+	}
+	singer.resolveNextSong()
+
+	return singer
+}
+
+func (singer *offscreenSinger) update() {
+	if singer.singing {
+		select {
+		case <-singer.doneSinging:
+			singer.stopSinging()
+		default:
+		}
+
+		if soundDisabled {
+			singer.stopSinging()
+		}
+	} else if time.Now().After(singer.singingStartTime) {
+		if soundDisabled || !singer.chosenToSing {
+			singer.resolveNextSong()
+		} else {
+			singer.sing()
+		}
+	}
+}
+
+func (singer *offscreenSinger) sing() {
+	buffer := soundBuffers[singer.species.Song()]
+	songs.started(singer, singer.answerExchange, singer.answering, buffer)
+
+	announceSong(singer, buffer)
+
+	singer.singing = true
+	singer.song = playPositionedSound(ambienceBus, buffer, singer.position, singer.doneSinging)
+}
+
+func (singer *offscreenSinger) stopSinging() {
+	singer.singing = false
+	singer.resolveNextSong()
+}
+
+func (singer *offscreenSinger) resolveNextSong() {
+	singer.singingStartTime, singer.chosenToSing = resolveNextSong(singer.species)
+	singer.answering = nil
+	singer.answerExchange = 0
+}
+
+// The birds singing out of sight. More of them join in for the dawn and evening choruses, arriving a little apart so
+// their songs overlap in succession rather than all at once.
+type offscreenChorus struct {
+	singers         []*offscreenSinger
+	nextArrivalTime time.Time
+}
+
+var chorus = &offscreenChorus{}
+
+func (chorus *offscreenChorus) update() {
+	// Let go of anyone due to leave, once they've finished their song.
+	remaining := []*offscreenSinger{}
+	for _, singer := range chorus.singers {
+		if singer.singing || time.Now().Before(singer.leaveTime) {
+			remaining = append(remaining, singer)
+		}
+	}
+	chorus.singers = remaining
+
+	for _, singer := range chorus.singers {
+		singer.update()
+	}
+
+	if len(chorus.singers) >= chorus.size() || time.Now().Before(chorus.nextArrivalTime) {
+		return
+	}
+
+	chorus.nextArrivalTime = time.Now().Add(time.Second * time.Duration(nextRandomInt(offscreenSingerArrivalGapRange.min, offscreenSingerArrivalGapRange.max)))
+
+	// Anyone around in this region at this time of year can join in, not only the birds that visit the feeder, as long
+	// as there's a song for them to sing.
+	choices := []wr.Choice{}
+	for _, choice := range seasonalBirdChoices(time.Now()) {
+		if hasSong(choice.Item.(species)) {
+			choices = append(choices, choice)
+		}
+	}
+	if len(choices) == 0 {
+		return
+	}
+
+	chooser, _ := wr.NewChooser(choices...)
+	chorus.singers = append(chorus.singers, newOffscreenSinger(chooser.Pick().(species)))
+}
+
+// How many birds should be singing out of sight right now.
+func (chorus *offscreenChorus) size() int {
+	seasonalSize := dayPhaseOffscreenSingers[getDayPhase(time.Now())] * int(seasonSingingPercents[getSeason(time.Now())]) / 100
+	return seasonalSize * int(weather.effect().singingPercent) / 100
+}
+
+// Put back every arrival, song and departure planned, after the scene has stood still for the given length.
+func (chorus *offscreenChorus) postpone(length time.Duration) {
+	chorus.nextArrivalTime = postponed(chorus.nextArrivalTime, length)
+
+	for _, singer := range chorus.singers {
+		singer.leaveTime = postponed(singer.leaveTime, length)
+		singer.singingStartTime = postponed(singer.singingStartTime, length)
+	}
+}
+
+// Send every singer on its way. Songs already playing finish on their own.
+func (chorus *offscreenChorus) clear() {
+	chorus.singers = nil
+	chorus.nextArrivalTime = time.Time{}
+}
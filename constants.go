@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 type pair struct {
 	min, max int
 }
@@ -19,19 +21,142 @@ const (
 	defaultSeedRefillMultiplier = .05
 	defaultBirdFrameRate        = 1.0 / 10
 	standardHouseFeederName     = "Backyard Sunflower Feeder"
-	pauseMenuHeight             = float64(450)
+	pauseMenuHeight             = float64(690)
 	pauseMenuWidth              = float64(1000)
 	spawnRandomnessOffset       = 500
 	likelihoodMaxPercent        = 1000
 	animationMappingsFile       = "animationMap/animationMappings.csv"
+	defaultAnimationFile        = "animationMap/default.json"
 	standardSpriteWidth         = 43
 
+	// Empty rows left between sheets in the bird atlas, so neighbouring frames don't bleed into each other.
+	atlasPadding = 1
+
 	// Extremely rough 'pretend' times.
 	defaultSunriseHour = 7
 	defaultSunsetHour  = 19
 
 	// Chances of a bird showing up at night is 1 in this number.
 	defaultNumChancesOfNightBird = 1000000000000
+
+	// Flocking. Spread is how far from the first member the others spawn, the weights scale the boids steering,
+	// and the max nudge caps steering as a fraction of a single move.
+	flockSpawnSpread        = 150
+	flockSeparationDistance = 150
+	flockCohesionWeight     = .02
+	flockSeparationWeight   = .05
+	flockMaxNudge           = .5
+
+	// Likelihood (out of likelihoodMaxPercent) that a dominant bird chases another off a perch when the feeder is full.
+	defaultDisplacementLikelihood = 600
+	fleeingSpeedMultiplier        = 2
+
+	// Likelihood of a regional species showing up at a feeder context that doesn't list it.
+	defaultRegionalLikelihood = 100
+
+	// How long before rain or snow birds start feeding up, and by how much (as percentages).
+	stormWarningLength   = 30 * time.Minute
+	stormActivityPercent = 150
+	stormEatingPercent   = 150
+
+	// How often a weather provider is read, how long to wait on it, and how long a report without a forecast holds
+	// before the simulation takes over again.
+	weatherPollInterval    = time.Minute
+	weatherProviderTimeout = 5 * time.Second
+	weatherReportLifetime  = 30 * time.Minute
+
+	// Mixer volumes run from 0 to 1, changing by a step at a time in the menu, and gliding over the fade length.
+	defaultMasterVolume = .8
+	defaultBusVolume    = 1
+	volumeStep          = .1
+	mixerFadeLength     = 250 * time.Millisecond
+
+	// Control messages to the ambience player beyond the queue length wait for it to catch up.
+	ambienceMessageQueueLength = 8
+
+	// Songs are panned at most this far to either side, and heard at full volume within this distance of the middle
+	// of the screen. Beyond that they fade, down to the minimum attenuation.
+	maxSongPan             = .8
+	songFullVolumeDistance = 600
+	minimumAttenuation     = .01
+
+	// Birds singing out of sight do so from somewhere between these distances beyond the edge of the screen.
+	offscreenSingerMinDistance = 300
+	offscreenSingerMaxDistance = 1500
+
+	// Distant calls come from somewhere between these distances from the middle of the screen.
+	soundscapeMinDistance = 900
+	soundscapeMaxDistance = 3000
+
+	// Birds only answer songs from within this distance.
+	responseMaxDistance = 3000
+
+	// Likelihood (out of likelihoodMaxPercent) of a bird calling as it flies in or out.
+	flightCallLikelihood = 300
+
+	// Quality used when resampling sounds to the speaker's sample rate.
+	resampleQuality = 4
+
+	// Seed flicked off the feeder falls this fast (in pixels per second squared), for at most this many seconds.
+	seedParticleGravity  = 600
+	seedParticleLifetime = 1.5
+
+	// Captions stay up for at least this long, with text at this scale and this much padding around it.
+	minimumCaptionLength = 2 * time.Second
+	captionTextScale     = 2.0
+	captionNoteWidth     = 18.0
+	captionPadding       = 8
+
+	// The song visualisation panel. The window (in samples, a power of two) is analysed into this many bands, and
+	// this many columns of the spectrogram are kept. Anything quieter than the noise floor (in decibels) isn't drawn.
+	visualiserWidth        = 480
+	visualiserHeight       = 240
+	visualiserWindowLength = 1024
+	visualiserBands        = 48
+	visualiserColumns      = 120
+	visualiserNoiseFloor   = -70
+
+	// Where settings are saved, inside the user's config directory.
+	settingsDirectoryName = "Feedr"
+	settingsFileName      = "settings.json"
+
+	// In display mode, a scene with nothing moving in it is only drawn this many times a second.
+	idleFrameRate = 15
+
+	// Refill policies. The scheduled refill happens from this hour each morning, the feeder is topped up once it's
+	// down to this fraction, and a seed bag holds this many feeder-fulls.
+	scheduledRefillHour = 8
+	refillThreshold     = .2
+	seedBagFeederFills  = 10
+
+	// The seed economy. Coins earned for each bird seen, the first of each species, each song heard and each visit
+	// lasting at least longVisitLength, and what a new seed bag costs. Decorations make visits last longer and the gaps
+	// between birds shorter by these factors.
+	sightingReward           = 2
+	newSpeciesReward         = 50
+	songReward               = 1
+	longVisitReward          = 10
+	longVisitLength          = 2 * time.Minute
+	seedBagCost              = 160
+	birdbathVisitMultiplier  = 1.25
+	brushPileSpawnMultiplier = .8
+
+	// Achievements. How many birds make a full feeder, how long Feedr has to run without it going empty, and how long
+	// each toast shows for.
+	fullFeederBirdCount    = 5
+	neverEmptyLength       = 7 * 24 * time.Hour
+	achievementToastLength = 4 * time.Second
+
+	// Photo mode. How fast the camera pans (in scene units a second), how much each step zooms in and how far it can
+	// go, and how long the flash lasts. Photos are saved in their own directory next to the settings, and the
+	// gallery reads only this much of each to find its details, listing this many at a time.
+	photoPanSpeed         = 600
+	photoZoomStep         = 1.25
+	photoMaxZoom          = 4.0
+	photoFlashLength      = 300 * time.Millisecond
+	photosDirectoryName   = "photos"
+	photoHeaderReadLength = 4096
+	galleryPageLength     = 10
 )
 
 type animState int
@@ -54,6 +179,22 @@ const (
 	insane
 )
 
+// How far apart (in seconds) birds join the off-screen chorus, and how long they stay.
+var offscreenSingerArrivalGapRange = pair{5, 40}
+var offscreenSingerStayRange = pair{120, 900}
+
+// How far apart (in seconds) distant calls are, before the time of day and weather have their say.
+var soundscapeGapRange = pair{4, 30}
+
+// How long (in seconds) a perched bird sits still between idle clips.
+var idleGapRange = pair{3, 12}
+
+// How many bits of seed a peck sends flying.
+var seedParticleBurstRange = pair{2, 6}
+
+// How long (in milliseconds) after a song ends another bird answers it.
+var responseDelayRange = pair{500, 3000}
+
 // List of each available feeder 'context' in the game.
 var feederContextMappings = map[string]feederContext{
 	standardHouseFeederName: &standardHouseFeeder{},
@@ -71,7 +212,7 @@ var menuTitleText = "Welcome to Feeder."
 var menuSubtitleText = "Select a feeder scene with the arrow keys, then press enter.\n"
 
 // The text to show at the bottom of the menu.
-var menuAdditionalText = "\nPress enter repeatedly to refill the feeder. \nPress escape to access/exit this menu.\n"
+var menuAdditionalText = "\nPress enter repeatedly to refill the feeder (or pick how it's refilled above). \nPress escape to access/exit this menu. \nPress P to take photos.\n"
 
 // When an update is found.
 var updateRequiredText = "\nNew version with more birds/feeders available at feeder.com!\n"
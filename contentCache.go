@@ -0,0 +1,134 @@
+package main
+
+import (
+	"github.com/faiface/pixel"
+	"github.com/pkg/errors"
+)
+
+// Everything loaded from disk, kept so that each file is only ever read once. Bird sprite sheets are packed into one
+// shared atlas, so that every bird on screen can be drawn in a single batch.
+type contentCache struct {
+	pictures map[string]pixel.Picture
+
+	// Each species' clips, by sprite sheet path, with their frames moved to where the sheet sits in the atlas.
+	birdClips map[string]map[string]*animationClip
+
+	atlas *spriteAtlas
+}
+
+var content = newContentCache()
+
+func newContentCache() *contentCache {
+	return &contentCache{
+		pictures:  map[string]pixel.Picture{},
+		birdClips: map[string]map[string]*animationClip{},
+		atlas:     &spriteAtlas{},
+	}
+}
+
+func (cache *contentCache) picture(path string) (pixel.Picture, error) {
+	if picture, found := cache.pictures[path]; found {
+		return picture, nil
+	}
+
+	picture, err := loadPicture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.pictures[path] = picture
+	return picture, nil
+}
+
+// A species' animation clips, loading its sheet into the atlas the first time it's asked for. The clips are shared by
+// every bird of the species, so they must never be changed.
+func (cache *contentCache) birdAnimations(sheetPath string) (map[string]*animationClip, error) {
+	if clips, found := cache.birdClips[sheetPath]; found {
+		return clips, nil
+	}
+
+	sheet, clips, err := loadBirdAnimations(sheetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := cache.atlas.add(sheet)
+	for _, clip := range clips {
+		for index := range clip.frames {
+			clip.frames[index].rect = clip.frames[index].rect.Moved(offset)
+		}
+	}
+
+	cache.birdClips[sheetPath] = clips
+	return clips, nil
+}
+
+// Load the sheets of all the given species up front, so that nobody waits on the disk when a bird first turns up.
+func (cache *contentCache) preloadBirds(species []species) error {
+	for _, species := range species {
+		if _, err := cache.birdAnimations(species.Animation()); err != nil {
+			return errors.Wrap(err, "error preloading "+species.Name())
+		}
+	}
+
+	return nil
+}
+
+// Sprite sheets stacked one above the other in a single picture. Sheets are only ever added on top, so a frame's
+// place in the atlas never changes once it's there.
+type spriteAtlas struct {
+	picture *pixel.PictureData
+
+	// Everything drawn from the atlas in a frame. Made again whenever the atlas grows, as it's tied to the picture.
+	batch *pixel.Batch
+}
+
+// Copy a sheet into the atlas, returning how far its frames have moved.
+func (atlas *spriteAtlas) add(sheet pixel.Picture) pixel.Vec {
+	data := pixel.PictureDataFromPicture(sheet)
+	sheetWidth := int(data.Rect.W())
+	sheetHeight := int(data.Rect.H())
+
+	oldWidth, oldHeight := 0, 0
+	if atlas.picture != nil {
+		oldWidth = int(atlas.picture.Rect.W())
+		oldHeight = int(atlas.picture.Rect.H()) + atlasPadding
+	}
+
+	width := oldWidth
+	if sheetWidth > width {
+		width = sheetWidth
+	}
+
+	grown := pixel.MakePictureData(pixel.R(0, 0, float64(width), float64(oldHeight+sheetHeight)))
+
+	// Pixels are stored a row at a time from the bottom up, so the old atlas is copied in as is, and the sheet above it.
+	if atlas.picture != nil {
+		for y := 0; y < int(atlas.picture.Rect.H()); y++ {
+			from := atlas.picture.Index(pixel.V(0, float64(y)))
+			to := grown.Index(pixel.V(0, float64(y)))
+			copy(grown.Pix[to:to+oldWidth], atlas.picture.Pix[from:from+oldWidth])
+		}
+	}
+
+	for y := 0; y < sheetHeight; y++ {
+		from := data.Index(pixel.V(data.Rect.Min.X, data.Rect.Min.Y+float64(y)))
+		to := grown.Index(pixel.V(0, float64(oldHeight+y)))
+		copy(grown.Pix[to:to+sheetWidth], data.Pix[from:from+sheetWidth])
+	}
+
+	atlas.picture = grown
+	atlas.batch = nil
+
+	return pixel.V(-data.Rect.Min.X, float64(oldHeight)-data.Rect.Min.Y)
+}
+
+// The batch to draw birds into this frame, emptied of last frame's.
+func (atlas *spriteAtlas) begin() *pixel.Batch {
+	if atlas.batch == nil {
+		atlas.batch = pixel.NewBatch(&pixel.TrianglesData{}, atlas.picture)
+	}
+
+	atlas.batch.Clear()
+	return atlas.batch
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/faiface/pixel"
+)
+
+// A decoration from the shop, as it sits in a feeder context's scene. Like the seed, it has a picture for each time
+// of day.
+type decoration struct {
+	// Where the middle of the decoration sits in the scene.
+	position pixel.Vec
+	pictures map[string]pixel.Picture
+	sprite   *pixel.Sprite
+}
+
+func newDecoration(position pixel.Vec, pictures map[string]pixel.Picture) *decoration {
+	return &decoration{position: position, pictures: pictures, sprite: pixel.NewSprite(nil, pixel.Rect{})}
+}
+
+// Draw the decorations that have been bought, in the order they're listed in the shop. They're drawn at the
+// background's scale so their pixels line up with it.
+func drawDecorations(target pixel.Target, background pixel.Picture) {
+	fill := backgroundFill(background)
+
+	for _, item := range shopItems {
+		decoration, found := context.Decorations()[item.name]
+		if !found || !economy.showsDecoration(item.name) {
+			continue
+		}
+
+		picture := decoration.pictures[getTimeOfDay()]
+		decoration.sprite.Set(picture, picture.Bounds())
+		decoration.sprite.Draw(target, pixel.IM.Scaled(pixel.ZV, fill).Moved(decoration.position))
+	}
+}
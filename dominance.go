@@ -0,0 +1,57 @@
+package main
+
+import "math/rand"
+
+// Chase a lower ranked bird off its feeder perch so that the given species can have it. Returns the freed perch.
+func displaceBird(birds []*bird, species species) (bool, *perch) {
+	victims := []*bird{}
+	for _, occupant := range birds {
+		if canDisplace(species, occupant) {
+			victims = append(victims, occupant)
+		}
+	}
+
+	if len(victims) == 0 {
+		return false, &perch{}
+	}
+
+	// Even a dominant bird doesn't always bother picking a fight.
+	if nextRandomInt(0, likelihoodMaxPercent) >= defaultDisplacementLikelihood {
+		return false, &perch{}
+	}
+
+	victim := victims[rand.Intn(len(victims))]
+	takenPerch := victim.perch
+	victim.flee()
+
+	return true, takenPerch
+}
+
+func canDisplace(species species, occupant *bird) bool {
+	// Only birds settled on the feeder can be chased off. Singing birds get to finish their song.
+	if !occupant.perched || occupant.singing || occupant.perch.waiting {
+		return false
+	}
+
+	perchWidth := occupant.perch.X.max - occupant.perch.X.min
+	perchHeight := occupant.perch.Y.max - occupant.perch.Y.min
+
+	// The perch must also be able to fit the bird doing the chasing.
+	if perchWidth < species.Width() || perchHeight < species.Height() {
+		return false
+	}
+
+	return species.DominanceRank() > occupant.species.DominanceRank()
+}
+
+func (bird *bird) flee() {
+	// The old perch now belongs to the bird that chased this one off, so it stays occupied.
+	// Hop over to any other free perch, otherwise leave altogether.
+	if perchFound, newPerch := getRandomPerch(bird.species); perchFound {
+		bird.headForPerch(newPerch)
+	} else if waitingPerches := getRandomPerches(context.WaitingPerches(), bird.species, 1); len(waitingPerches) != 0 {
+		bird.headForPerch(waitingPerches[0])
+	} else {
+		bird.setFleeingStatus()
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// The optional idle game layered over the feeder: seed costs coins, coins are earned by watching the birds, and
+// coins buy seed and decorations. It's off unless turned on from the pause menu.
+type seedEconomy struct {
+	enabled bool
+	coins   int
+
+	// Species seen since the economy was turned on, by name. Each is worth a bonus the first time.
+	seenSpecies map[string]bool
+
+	// Everything bought, by name.
+	unlocked map[string]bool
+
+	// The seed in the feeder, by name.
+	seedType string
+
+	text *text.Text
+}
+
+var economy = &seedEconomy{seenSpecies: map[string]bool{}, unlocked: map[string]bool{}, seedType: defaultSeedTypeName}
+
+// Start earning from what happens in the backyard.
+func (economy *seedEconomy) Initialize() {
+	simulationEvents.subscribe(economy.onEvent)
+}
+
+func (economy *seedEconomy) onEvent(event simulationEvent) {
+	if !economy.enabled {
+		return
+	}
+
+	switch event.kind {
+	case birdArrived:
+		economy.earn(sightingReward)
+		if !economy.seenSpecies[event.species.Name()] {
+			economy.seenSpecies[event.species.Name()] = true
+			economy.earn(newSpeciesReward)
+		}
+	case songHeard:
+		economy.earn(songReward)
+	case birdDeparted:
+		if event.visitLength >= longVisitLength {
+			economy.earn(longVisitReward)
+		}
+
+		// Departures come often enough to save the coins by, without writing the settings on every song.
+		saveSettings()
+	}
+}
+
+func (economy *seedEconomy) earn(coins int) {
+	economy.coins += coins
+}
+
+// Pay for something, if there's enough to. Everything is free with the economy off.
+func (economy *seedEconomy) spend(coins int) bool {
+	if !economy.enabled {
+		return true
+	}
+
+	if economy.coins < coins {
+		return false
+	}
+
+	economy.coins -= coins
+	return true
+}
+
+func (economy *seedEconomy) currentSeedType() seedType {
+	if seed, found := seedTypes[economy.seedType]; found {
+		return seed
+	}
+
+	return seedTypes[defaultSeedTypeName]
+}
+
+// How much of the given amount of seed can be paid for, for a feeder holding feederSize.
+func (economy *seedEconomy) affordableSeed(amount, feederSize float64) float64 {
+	if !economy.enabled {
+		return amount
+	}
+
+	return math.Min(amount, float64(economy.coins)/float64(economy.currentSeedType().price)*feederSize)
+}
+
+// Pay for seed poured into the feeder, to the nearest coin.
+func (economy *seedEconomy) payForSeed(amount, feederSize float64) {
+	if !economy.enabled {
+		return
+	}
+
+	cost := int(math.Round(amount / feederSize * float64(economy.currentSeedType().price)))
+	economy.coins = int(math.Max(0, float64(economy.coins-cost)))
+}
+
+// Buy whatever's named in the shop, or switch to it if it's seed that's already been bought. Returns whether it's
+// now owned.
+func (economy *seedEconomy) buy(item shopItem) bool {
+	if !economy.owns(item) {
+		if !economy.spend(item.cost) {
+			return false
+		}
+
+		economy.unlocked[item.name] = true
+	}
+
+	if item.kind == seedUnlock {
+		economy.seedType = item.name
+	}
+
+	return true
+}
+
+func (economy *seedEconomy) owns(item shopItem) bool {
+	return item.cost == 0 || economy.unlocked[item.name]
+}
+
+// How much likelier a species is to show up, for the seed in the feeder.
+func (economy *seedEconomy) speciesMultiplier(species species) float64 {
+	if !economy.enabled {
+		return 1
+	}
+
+	if multiplier, found := economy.currentSeedType().likelihoods[species.Name()]; found {
+		return multiplier
+	}
+
+	return 1
+}
+
+// Decorations only show up (and do anything) once bought, and only while the economy is on.
+func (economy *seedEconomy) showsDecoration(name string) bool {
+	return economy.enabled && economy.unlocked[name]
+}
+
+func (economy *seedEconomy) decorationMultiplier(name string, multiplier float64) float64 {
+	if economy.showsDecoration(name) {
+		return multiplier
+	}
+
+	return 1
+}
+
+func (economy *seedEconomy) visitLengthMultiplier() float64 {
+	return economy.decorationMultiplier(birdbathName, birdbathVisitMultiplier)
+}
+
+func (economy *seedEconomy) spawnGapMultiplier() float64 {
+	return economy.decorationMultiplier(brushPileName, brushPileSpawnMultiplier)
+}
+
+// Show the coins in the corner while the economy is on.
+func (economy *seedEconomy) draw(target pixel.Target) {
+	if !economy.enabled {
+		return
+	}
+
+	if economy.text == nil {
+		economy.text = text.New(pixel.ZV, text.NewAtlas(basicfont.Face7x13, text.ASCII))
+	}
+
+	economy.text.Clear()
+	economy.text.Color = color.RGBA{R: 250, G: 220, B: 120, A: 255}
+	fmt.Fprintf(economy.text, "%d coins", economy.coins)
+
+	corner := pixel.V(layout.visibleBounds.Min.X+captionPadding, layout.visibleBounds.Max.Y-captionPadding-economy.text.Bounds().H()*captionTextScale)
+	economy.text.Draw(target, pixel.IM.Scaled(pixel.ZV, captionTextScale).Moved(corner))
+}
+
+// The species seen so far, for saving.
+func (economy *seedEconomy) seenSpeciesNames() []string {
+	return sortedNames(economy.seenSpecies)
+}
+
+func (economy *seedEconomy) unlockedNames() []string {
+	return sortedNames(economy.unlocked)
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := []string{}
+	for name, included := range set {
+		if included {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Pour seed into the feeder, paying for it when the economy is on. Returns how much went in.
+func pourSeed(amount float64) float64 {
+	seed := context.Seed()
+
+	added := seed.add(economy.affordableSeed(amount, seed.originalSeedCount))
+	economy.payForSeed(added, seed.originalSeedCount)
+
+	return added
+}
@@ -0,0 +1,53 @@
+package main
+
+import "time"
+
+type simulationEventKind int
+
+const (
+	// A bird has turned up at the feeder.
+	birdArrived simulationEventKind = iota
+
+	// A bird has flown off again, after a visit of visitLength.
+	birdDeparted
+
+	// Someone started singing, on screen or off.
+	songHeard
+
+	// The birds have eaten every last seed.
+	feederEmptied
+)
+
+// Something that happened in the backyard, for anything keeping track (coins, achievements) to react to.
+type simulationEvent struct {
+	kind    simulationEventKind
+	time    time.Time
+	species species
+
+	// Arrivals and departures carry the bird, and songs their singer.
+	bird        *bird
+	singer      singer
+	visitLength time.Duration
+}
+
+// Passes events on to everyone subscribed, as they happen. Events are only published from the game loop, so
+// handlers run there too and can't hold it up for long.
+type simulationEventBus struct {
+	subscribers []func(event simulationEvent)
+}
+
+var simulationEvents = &simulationEventBus{}
+
+func (bus *simulationEventBus) subscribe(handler func(event simulationEvent)) {
+	bus.subscribers = append(bus.subscribers, handler)
+}
+
+func (bus *simulationEventBus) publish(event simulationEvent) {
+	if event.time.IsZero() {
+		event.time = time.Now()
+	}
+
+	for _, handler := range bus.subscribers {
+		handler(event)
+	}
+}
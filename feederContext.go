@@ -17,25 +17,31 @@ type feederContext interface {
 	SingingGapRanges() map[timeLength]pair
 	BirdLikelihoods() map[species]uint
 	Perches() []*perch
+	WaitingPerches() []*perch
 	Seed() *birdSeed
 	Sounds() map[string]string
 	Backgrounds() map[string]pixel.Picture
+	SeasonalBackgrounds() map[season]map[string]pixel.Picture
 	Seeds() map[string]pixel.Picture
+	Decorations() map[string]*decoration
 }
 
 type standardHouseFeeder struct {
-	timeLikelihoods    map[timeLength]uint
-	spawnLengthRanges  map[timeLength]pair
-	feederLengthRanges map[timeLength]pair
-	eatingLengthRanges map[timeLength]pair
-	eatingGapRanges    map[timeLength]pair
-	singingGapRanges   map[timeLength]pair
-	birdLikelihoods    map[species]uint
-	perches            []*perch
-	seed               *birdSeed
-	sounds             map[string]string
-	backgrounds        map[string]pixel.Picture
-	seeds              map[string]pixel.Picture
+	timeLikelihoods     map[timeLength]uint
+	spawnLengthRanges   map[timeLength]pair
+	feederLengthRanges  map[timeLength]pair
+	eatingLengthRanges  map[timeLength]pair
+	eatingGapRanges     map[timeLength]pair
+	singingGapRanges    map[timeLength]pair
+	birdLikelihoods     map[species]uint
+	perches             []*perch
+	waitingPerches      []*perch
+	seed                *birdSeed
+	sounds              map[string]string
+	backgrounds         map[string]pixel.Picture
+	seasonalBackgrounds map[season]map[string]pixel.Picture
+	seeds               map[string]pixel.Picture
+	decorations         map[string]*decoration
 }
 
 func (standardHouseFeeder *standardHouseFeeder) Initialize() {
@@ -98,6 +104,8 @@ func (standardHouseFeeder *standardHouseFeeder) Initialize() {
 		&downyWoodpecker{}: 300,
 		&chickadee{}:       300,
 		&titmouse{}:        280,
+		&junco{}:           250,
+		&warbler{}:         150,
 	}
 
 	standardHouseFeeder.perches = []*perch{
@@ -105,14 +113,22 @@ func (standardHouseFeeder *standardHouseFeeder) Initialize() {
 		{X: &coordinatePair{270, 460}, Y: &coordinatePair{-350, -129}, occupied: false},
 	}
 
+	standardHouseFeeder.waitingPerches = []*perch{
+		{X: &coordinatePair{-640, -450}, Y: &coordinatePair{210, 431}, occupied: false, waiting: true, area: "Branch"},
+		{X: &coordinatePair{600, 790}, Y: &coordinatePair{160, 381}, occupied: false, waiting: true, area: "Branch"},
+		{X: &coordinatePair{-430, -240}, Y: &coordinatePair{94, 315}, occupied: false, waiting: true, area: "Fence"},
+		{X: &coordinatePair{-200, -10}, Y: &coordinatePair{94, 315}, occupied: false, waiting: true, area: "Fence"},
+		{X: &coordinatePair{150, 340}, Y: &coordinatePair{60, 281}, occupied: false, waiting: true, area: "Roofline"},
+		{X: &coordinatePair{380, 570}, Y: &coordinatePair{60, 281}, occupied: false, waiting: true, area: "Roofline"},
+	}
+
 	standardHouseFeeder.seed = newBirdSeed(pixel.V(0, 0), 300, 700, 3000, 1.15, 1.15, 100, -300, 100)
 
+	// Songs are listed by species name and calls as "<name> call", only where there's a recording of that species.
+	// Species without one stay quiet.
 	standardHouseFeeder.sounds = map[string]string{
-		"Black-capped Chickadee": "sounds/songs/downyWoodpeckerSong.mp3",
-		"Tufted Titmouse":        "sounds/songs/downyWoodpeckerSong.mp3",
-		"Northern Cardinal":      "sounds/songs/downyWoodpeckerSong.mp3",
-		"Downy Woodpecker":       "sounds/songs/downyWoodpeckerSong.mp3",
-		"Background":             "sounds/background/ambience.mp3",
+		"Downy Woodpecker": "sounds/songs/downyWoodpeckerSong.mp3",
+		"Background":       "sounds/background/ambience.mp3",
 	}
 
 	standardHouseFeeder.backgrounds = map[string]pixel.Picture{
@@ -121,11 +137,34 @@ func (standardHouseFeeder *standardHouseFeeder) Initialize() {
 		"dusk":  getPixelPicture("sprites/backgrounds/backyardSunflowerDuskEmpty.png"),
 	}
 
+	// Snow in winter. The other seasons use the standard backgrounds.
+	standardHouseFeeder.seasonalBackgrounds = map[season]map[string]pixel.Picture{
+		winter: {
+			"night": getPixelPicture("sprites/backgrounds/backyardSunflowerWinterNightEmpty.png"),
+			"day":   getPixelPicture("sprites/backgrounds/backyardSunflowerWinterDayEmpty.png"),
+			"dusk":  getPixelPicture("sprites/backgrounds/backyardSunflowerWinterDuskEmpty.png"),
+		},
+	}
+
 	standardHouseFeeder.seeds = map[string]pixel.Picture{
 		"night": getPixelPicture("sprites/seeds/sunflowerSeedPileNight.png"),
 		"day":   getPixelPicture("sprites/seeds/sunflowerSeedPileDay.png"),
 		"dusk":  getPixelPicture("sprites/seeds/sunflowerSeedPileDusk.png"),
 	}
+
+	// The birdbath stands out on the lawn, and the brush pile sits in the corner under the flowers.
+	standardHouseFeeder.decorations = map[string]*decoration{
+		birdbathName: newDecoration(pixel.V(-225, -355), map[string]pixel.Picture{
+			"night": getPixelPicture("sprites/decorations/birdbathNight.png"),
+			"day":   getPixelPicture("sprites/decorations/birdbathDay.png"),
+			"dusk":  getPixelPicture("sprites/decorations/birdbathDusk.png"),
+		}),
+		brushPileName: newDecoration(pixel.V(-660, -395), map[string]pixel.Picture{
+			"night": getPixelPicture("sprites/decorations/brushPileNight.png"),
+			"day":   getPixelPicture("sprites/decorations/brushPileDay.png"),
+			"dusk":  getPixelPicture("sprites/decorations/brushPileDusk.png"),
+		}),
+	}
 }
 
 func (standardHouseFeeder *standardHouseFeeder) Name() string {
@@ -164,6 +203,10 @@ func (standardHouseFeeder *standardHouseFeeder) Perches() []*perch {
 	return standardHouseFeeder.perches
 }
 
+func (standardHouseFeeder *standardHouseFeeder) WaitingPerches() []*perch {
+	return standardHouseFeeder.waitingPerches
+}
+
 func (standardHouseFeeder *standardHouseFeeder) Seed() *birdSeed {
 	return standardHouseFeeder.seed
 }
@@ -176,6 +219,14 @@ func (standardHouseFeeder *standardHouseFeeder) Backgrounds() map[string]pixel.P
 	return standardHouseFeeder.backgrounds
 }
 
+func (standardHouseFeeder *standardHouseFeeder) SeasonalBackgrounds() map[season]map[string]pixel.Picture {
+	return standardHouseFeeder.seasonalBackgrounds
+}
+
 func (standardHouseFeeder *standardHouseFeeder) Seeds() map[string]pixel.Picture {
 	return standardHouseFeeder.seeds
 }
+
+func (standardHouseFeeder *standardHouseFeeder) Decorations() map[string]*decoration {
+	return standardHouseFeeder.decorations
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+	wr "github.com/mroth/weightedrand"
+)
+
+// A group of birds of the same species that arrived together.
+type flock struct {
+	members []*bird
+}
+
+func (flock *flock) add(member *bird) {
+	// Everyone after the first member spawns close to the first, so the flock arrives from the same direction.
+	if len(flock.members) > 0 {
+		leaderLocation := flock.members[0].physics.rect
+		offset := pixel.V(
+			nextRandomFloat64(-flockSpawnSpread, flockSpawnSpread),
+			nextRandomFloat64(-flockSpawnSpread, flockSpawnSpread),
+		)
+
+		member.physics.rect = leaderLocation.Moved(awayFromView(leaderLocation, offset))
+		member.initEntryMoves()
+	}
+
+	member.flock = flock
+	flock.members = append(flock.members, member)
+}
+
+// Turn an offset from a bird just out of sight so that it points further out of sight, on the side the bird is on,
+// so that flockmates spread around it fly in too rather than popping up inside the scene.
+func awayFromView(location pixel.Rect, offset pixel.Vec) pixel.Vec {
+	visible := layout.visibleBounds
+
+	switch {
+	case location.Max.X <= visible.Min.X:
+		offset.X = -math.Abs(offset.X)
+	case location.Min.X >= visible.Max.X:
+		offset.X = math.Abs(offset.X)
+	case location.Min.Y >= visible.Max.Y:
+		offset.Y = math.Abs(offset.Y)
+	case location.Max.Y <= visible.Min.Y:
+		offset.Y = -math.Abs(offset.Y)
+	}
+
+	return offset
+}
+
+// Boids-style steering for a member: pulled towards the rest of the flock, pushed away from flockmates too close by.
+func (flock *flock) steer(member *bird) pixel.Vec {
+	position := member.physics.rect.Center()
+	cohesion := pixel.ZV
+	separation := pixel.ZV
+	neighbours := 0
+
+	for _, other := range flock.members {
+		// Only flockmates still flying in have any influence.
+		if other == member || !other.entering {
+			continue
+		}
+
+		otherPosition := other.physics.rect.Center()
+		cohesion = cohesion.Add(otherPosition)
+		neighbours++
+
+		offset := position.Sub(otherPosition)
+		if distance := offset.Len(); distance > 0 && distance < flockSeparationDistance {
+			separation = separation.Add(offset.Scaled((flockSeparationDistance - distance) / distance))
+		}
+	}
+
+	if neighbours == 0 {
+		return pixel.ZV
+	}
+
+	// Head towards the center of the other members.
+	cohesion = cohesion.Scaled(1 / float64(neighbours)).Sub(position)
+
+	return cohesion.Scaled(flockCohesionWeight).Add(separation.Scaled(flockSeparationWeight))
+}
+
+// Head straight for the perch, nudged by the flock. The nudge fades out as the bird closes in so that
+// every member still lands exactly on its own perch.
+func (bird *bird) nextFlockMove(xDifference, yDifference, moveLength float64) (float64, float64) {
+	remainingDistance := math.Hypot(xDifference, yDifference)
+	heading := pixel.V(xDifference, yDifference).Scaled(moveLength / remainingDistance)
+
+	fade := math.Min(1, remainingDistance/math.Hypot(bird.xPerchDistance, bird.yPerchDistance))
+	nudge := bird.flock.steer(bird).Scaled(fade)
+
+	// Never let the nudge overpower the heading, otherwise a bird could circle its perch forever.
+	if nudge.Len() > moveLength*flockMaxNudge {
+		nudge = nudge.Unit().Scaled(moveLength * flockMaxNudge)
+	}
+
+	move := heading.Add(nudge)
+	return move.X, move.Y
+}
+
+func resolveFlockSize(species species) int {
+	// Enumerate all flock size choices.
+	choices := []wr.Choice{}
+	for size, likelihood := range species.FlockSizeLikelihoods() {
+		choices = append(choices, wr.Choice{Item: size, Weight: likelihood})
+	}
+
+	// Species without a flock size distribution always show up alone.
+	if len(choices) == 0 {
+		return 1
+	}
+
+	// Initialize a weighted probability flock size chooser.
+	chooser, err := wr.NewChooser(choices...)
+	if err != nil {
+		return 1
+	}
+
+	return chooser.Pick().(int)
+}
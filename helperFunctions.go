@@ -7,12 +7,13 @@ import (
 	"math/rand"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/faiface/pixel"
 	"github.com/pkg/errors"
 )
 
-func loadAnimationSheet(sheetPath, descPath string, frameWidth float64) (sheet pixel.Picture, anims map[string][]pixel.Rect, err error) {
+func loadAnimationSheet(sheetPath, descPath string, frameWidth float64) (sheet pixel.Picture, clips map[string]*animationClip, err error) {
 	// total hack, nicely format the error at the end, so I don't have to type it every time
 	defer func() {
 		if err != nil {
@@ -21,16 +22,10 @@ func loadAnimationSheet(sheetPath, descPath string, frameWidth float64) (sheet p
 	}()
 
 	// open and load the spritesheet
-	sheetFile, err := os.Open(sheetPath)
+	sheet, err = loadPicture(sheetPath)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer sheetFile.Close()
-	sheetImg, _, err := image.Decode(sheetFile)
-	if err != nil {
-		return nil, nil, err
-	}
-	sheet = pixel.PictureDataFromImage(sheetImg)
 
 	// create a slice of frames inside the spritesheet
 	var frames []pixel.Rect
@@ -49,7 +44,7 @@ func loadAnimationSheet(sheetPath, descPath string, frameWidth float64) (sheet p
 	}
 	defer descFile.Close()
 
-	anims = make(map[string][]pixel.Rect)
+	anims := make(map[string][]pixel.Rect)
 
 	// load the animation information, name and interval inside the spritesheet
 	desc := csv.NewReader(descFile)
@@ -69,7 +64,7 @@ func loadAnimationSheet(sheetPath, descPath string, frameWidth float64) (sheet p
 		anims[name] = frames[start : end+1]
 	}
 
-	return sheet, anims, nil
+	return sheet, clipsFromFrames(anims, defaultBirdFrameRate), nil
 }
 
 func nextRandomInt(min, max int) int {
@@ -80,17 +75,35 @@ func nextRandomFloat64(min, max float64) float64 {
 	return min + rand.Float64()*(max-min)
 }
 
+// A time put back by the given length. Times that were never set stay unset.
+func postponed(date time.Time, length time.Duration) time.Time {
+	if date.IsZero() {
+		return date
+	}
+
+	return date.Add(length)
+}
+
 func getPixelPicture(filePath string) pixel.Picture {
-	// open and load the spritesheet
-	sheetFile, err := os.Open(filePath)
+	picture, err := content.picture(filePath)
 	if err != nil {
 		panic(err)
 	}
-	defer sheetFile.Close()
-	sheetImg, _, err := image.Decode(sheetFile)
+
+	return picture
+}
+
+func loadPicture(filePath string) (pixel.Picture, error) {
+	// open and load the image
+	file, err := os.Open(filePath)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
 	}
 
-	return pixel.PictureDataFromImage(sheetImg)
+	return pixel.PictureDataFromImage(img), nil
 }
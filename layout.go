@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// How the scene fits a window that isn't the scene's shape.
+type aspectMode int
+
+const (
+	// Show the whole scene, with bars down the sides (or along the top and bottom).
+	letterboxAspect aspectMode = iota
+
+	// Fill the window, cutting off the edges of the scene. Best kept for windows close to the scene's shape, as the
+	// menus can end up cut off in a portrait window.
+	cropAspect
+
+	// Fill the window, showing more of the backyard around the scene.
+	extendAspect
+)
+
+var aspectModeNames = map[string]aspectMode{
+	"letterbox": letterboxAspect,
+	"crop":      cropAspect,
+	"extend":    extendAspect,
+}
+
+var windowWidthFlag = flag.Float64("width", winWidth, "width of the window, in pixels")
+var windowHeightFlag = flag.Float64("height", winHeight, "height of the window, in pixels")
+var fullscreenFlag = flag.Bool("fullscreen", false, "fill a monitor instead of opening a window")
+var monitorFlag = flag.Int("monitor", 0, "which monitor to go fullscreen on, counting from 0 (see -list-monitors)")
+var listMonitorsFlag = flag.Bool("list-monitors", false, "list the monitors to choose from and exit")
+var aspectFlag = flag.String("aspect", "letterbox", "how the scene fits a window of a different shape: "+aspectModeList())
+
+// Where the scene sits in the window. The scene is always winWidth by winHeight units around the origin, whatever
+// size the window is. Only how big it's drawn, and how much of it (or around it) shows, changes.
+type sceneLayout struct {
+	mode aspectMode
+
+	windowBounds pixel.Rect
+
+	// The part of the scene drawn on the canvas. Bigger than the scene itself when it's extended to fill the window.
+	canvasBounds pixel.Rect
+
+	// The part of the scene that can actually be seen. Smaller than the scene when it's cropped.
+	visibleBounds pixel.Rect
+
+	// Window pixels per scene unit.
+	scale float64
+}
+
+var layout = &sceneLayout{canvasBounds: sceneBounds(), visibleBounds: sceneBounds(), scale: 1}
+
+func sceneBounds() pixel.Rect {
+	return pixel.R(-winWidth/2, -winHeight/2, winWidth/2, winHeight/2)
+}
+
+func aspectModeList() string {
+	names := []string{}
+	for name := range aspectModeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// The window to open, as the flags ask for it.
+func windowConfig() (pixelgl.WindowConfig, error) {
+	mode, found := aspectModeNames[strings.ToLower(*aspectFlag)]
+	if !found {
+		return pixelgl.WindowConfig{}, fmt.Errorf("unknown aspect %q, expected one of %s", *aspectFlag, aspectModeList())
+	}
+	layout.mode = mode
+
+	if *windowWidthFlag <= 0 || *windowHeightFlag <= 0 {
+		return pixelgl.WindowConfig{}, fmt.Errorf("window size %vx%v is too small", *windowWidthFlag, *windowHeightFlag)
+	}
+
+	cfg := pixelgl.WindowConfig{
+		Title:     "Feeder",
+		Bounds:    pixel.R(0, 0, *windowWidthFlag, *windowHeightFlag),
+		VSync:     true,
+		Resizable: true,
+	}
+
+	// Fullscreen windows take on the monitor's own resolution. Display mode covers it without going fullscreen.
+	if displayModeEnabled() {
+		applyDisplayMode(&cfg)
+	} else if *fullscreenFlag {
+		monitor := chooseMonitor(*monitorFlag)
+		width, height := monitor.Size()
+
+		cfg.Monitor = monitor
+		cfg.Bounds = pixel.R(0, 0, width, height)
+	}
+
+	return cfg, nil
+}
+
+func chooseMonitor(index int) *pixelgl.Monitor {
+	monitors := pixelgl.Monitors()
+	if index < 0 || index >= len(monitors) {
+		fmt.Printf("there is no monitor %d, using the primary monitor\n", index)
+		return pixelgl.PrimaryMonitor()
+	}
+
+	return monitors[index]
+}
+
+func listMonitors() {
+	for index, monitor := range pixelgl.Monitors() {
+		width, height := monitor.Size()
+		fmt.Printf("%d: %s (%vx%v)\n", index, monitor.Name(), width, height)
+	}
+}
+
+// Work out the layout for the window's current size. Returns whether anything changed.
+func (layout *sceneLayout) fit(windowBounds pixel.Rect) bool {
+	// A minimised window has no size to fit, so keep things as they were until it's back.
+	if windowBounds == layout.windowBounds || windowBounds.W() <= 0 || windowBounds.H() <= 0 {
+		return false
+	}
+	layout.windowBounds = windowBounds
+
+	scene := sceneBounds()
+	widthScale := windowBounds.W() / scene.W()
+	heightScale := windowBounds.H() / scene.H()
+
+	// How much of the scene the window would show at the layout's scale.
+	windowInScene := func(scale float64) pixel.Rect {
+		halfSize := pixel.V(windowBounds.W()/scale/2, windowBounds.H()/scale/2)
+		return pixel.Rect{Min: halfSize.Scaled(-1), Max: halfSize}
+	}
+
+	switch layout.mode {
+	case cropAspect:
+		layout.scale = math.Max(widthScale, heightScale)
+		layout.canvasBounds = scene
+		layout.visibleBounds = windowInScene(layout.scale).Intersect(scene)
+	case extendAspect:
+		layout.scale = math.Min(widthScale, heightScale)
+		layout.canvasBounds = windowInScene(layout.scale)
+		layout.visibleBounds = layout.canvasBounds
+	default:
+		layout.scale = math.Min(widthScale, heightScale)
+		layout.canvasBounds = scene
+		layout.visibleBounds = scene
+	}
+
+	return true
+}
+
+// Keep the canvas the size the layout wants, following the window as it's resized.
+func fitCanvasToWindow(win *pixelgl.Window, canvas *pixelgl.Canvas) {
+	if layout.fit(win.Bounds()) && canvas.Bounds() != layout.canvasBounds {
+		canvas.SetBounds(layout.canvasBounds)
+	}
+}
+
+// Stretch the canvas to the window.
+func presentCanvas(win *pixelgl.Window, canvas *pixelgl.Canvas) {
+	fitCanvasToWindow(win, canvas)
+
+	win.SetMatrix(pixel.IM.Scaled(pixel.ZV, layout.scale).Moved(win.Bounds().Center()))
+	canvas.Draw(win, pixel.IM.Moved(canvas.Bounds().Center()))
+}
+
+// Draw the background picture so that it fills the scene, whatever size the picture is. When the scene is extended
+// past its edges, the picture is also stretched dimly across the whole canvas underneath, so the margins carry on the
+// colours of the backyard rather than sitting black.
+func drawBackground(target pixel.Target, picture pixel.Picture) {
+	bounds := picture.Bounds()
+	sprite := pixel.NewSprite(picture, bounds)
+	scene := sceneBounds()
+
+	if layout.canvasBounds != scene {
+		cover := math.Max(layout.canvasBounds.W()/bounds.W(), layout.canvasBounds.H()/bounds.H())
+		sprite.DrawColorMask(target, pixel.IM.Scaled(pixel.ZV, cover).Moved(layout.canvasBounds.Center()), pixel.RGB(.55, .55, .55))
+	}
+
+	sprite.Draw(target, pixel.IM.Scaled(pixel.ZV, backgroundFill(picture)).Moved(scene.Center()))
+}
+
+// How much the background picture is scaled by to fill the scene. Anything drawn over the background at the same
+// scale lines up with its pixels.
+func backgroundFill(picture pixel.Picture) float64 {
+	bounds := picture.Bounds()
+	scene := sceneBounds()
+
+	return math.Max(scene.W()/bounds.W(), scene.H()/bounds.H())
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -15,7 +16,6 @@ import (
 	wr "github.com/mroth/weightedrand"
 
 	"github.com/faiface/beep"
-	"github.com/faiface/beep/speaker"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
 	"github.com/faiface/pixel/pixelgl"
@@ -26,14 +26,14 @@ var nextBirdSpawnTime time.Time
 var context feederContext
 var soundBuffers = make(map[string]*beep.Buffer)
 
-// Set of channels that if flipped to 'true' terminate the background sound loop for their thread.
-var backgroundSoundControllers []chan bool
-var backgroundSoundControllerIndex int
-
 // Whether or not sound is globally disabled.
 var soundDisabled bool
 
+// The background and seed drawn last frame, kept until the pictures change.
+var backgroundPicture pixel.Picture
+var backgroundBounds pixel.Rect
 var backgroundImd *imdraw.IMDraw
+var seedsPicture pixel.Picture
 var seedsImd *imdraw.IMDraw
 
 var updateAvailableEndpoint = "https://n4jexxccj8.execute-api.us-east-2.amazonaws.com/default/UpdateAvailable"
@@ -43,11 +43,24 @@ func run() {
 	// Seed the randomness.
 	rand.Seed(time.Now().UnixNano())
 
-	// Establish the game window.
-	cfg := pixelgl.WindowConfig{
-		Title:  "Feeder",
-		Bounds: pixel.R(0, 0, winWidth, winHeight),
-		VSync:  true,
+	// Pick up where the user left off.
+	loadSettings()
+
+	// Monitors can only be listed once the window system is up, so it's done here rather than in main.
+	if *listMonitorsFlag {
+		listMonitors()
+		return
+	}
+
+	if embeddingRequested() {
+		noteUnsupportedEmbedding()
+		return
+	}
+
+	// Establish the game window, at the size (or on the monitor) asked for.
+	cfg, err := windowConfig()
+	if err != nil {
+		panic(err)
 	}
 
 	win, err := pixelgl.NewWindow(cfg)
@@ -55,12 +68,19 @@ func run() {
 		panic(err)
 	}
 
+	// Nobody needs a cursor over an ambient display.
+	if displayModeEnabled() {
+		win.SetCursorVisible(false)
+	}
+
 	// Establish new bird list.
 	birds := []*bird{}
 
 	// Establish the canvas.
-	canvas := pixelgl.NewCanvas(pixel.R(-winWidth/2, -winHeight/2, winWidth/2, winHeight/2))
+	canvas := pixelgl.NewCanvas(sceneBounds())
+	fitCanvasToWindow(win, canvas)
 	globalImd := imdraw.New(nil)
+	weatherImd := imdraw.New(nil)
 
 	// Establish a camera position.
 	camPos := pixel.ZV
@@ -68,9 +88,25 @@ func run() {
 	// Establish the new feeder context. Default to standard house feeder, and show feeder context selection menu.
 	initializeFeederContext(win, canvas, globalImd)
 
+	// Load every bird that might visit up front, packing their sheets into the atlas before the first one arrives.
+	visitors := []species{}
+	for species := range regionalBirdLikelihoods() {
+		visitors = append(visitors, species)
+	}
+	if err := content.preloadBirds(visitors); err != nil {
+		panic(err)
+	}
+
+	// Start off with clear skies. The weather changes on its own from there.
+	weather.Initialize()
+
 	// Initialize and buffer all game sounds, play background sounds.
 	initializeSounds()
 
+	// Earn coins from what happens, if the seed economy is on, and achievements always.
+	economy.Initialize()
+	achievements.Initialize()
+
 	// Initialize the pause menu.
 	pauseMenu := pauseMenu{}
 	pauseMenu.PreRender()
@@ -86,71 +122,78 @@ func run() {
 		elapsed := time.Since(last).Seconds()
 		last = time.Now()
 
-		// Escape shows the pause menu and allows the user to change feeder contexts (which will clear all birds).
+		// P takes the camera out: the scene stops still, to be looked around and photographed.
+		if win.JustPressed(pixelgl.KeyP) && !pauseMenu.open {
+			photo.toggle(camPos, birds)
+		}
+
+		// Escape shows the pause menu and allows the user to change feeder contexts (which will clear all birds). In
+		// photo mode, it puts the camera away instead.
 		pauseMenu.justOpenedMenu = false
-		if win.JustPressed(pixelgl.KeyEscape) && !pauseMenu.open {
+		if win.JustPressed(pixelgl.KeyEscape) && photo.active {
+			photo.toggle(camPos, birds)
+		} else if win.JustPressed(pixelgl.KeyEscape) && !pauseMenu.open {
 			pauseMenu.open = true
 			pauseMenu.justOpenedMenu = true
 		}
 
-		// Pressing enter will refill the seed by a constant percentage.
-		if win.JustPressed(pixelgl.KeyEnter) && !pauseMenu.open {
-			context.Seed().refill()
-		}
-
-		// Determine new birds / remove birds.
-		birds = resolveBirds(birds)
+		var cam pixel.Matrix
+		if photo.active {
+			// Nothing moves but the camera.
+			photo.update(win, elapsed)
+			cam = photo.camera()
+		} else {
+			// Pressing enter will refill the seed by a constant percentage.
+			if win.JustPressed(pixelgl.KeyEnter) && !pauseMenu.open {
+				refiller.refillByHand()
+
+				// Whatever came out of the seed bag stays out of it next time.
+				if refiller.policy == seedBagRefill {
+					saveSettings()
+				}
+			}
 
-		// Keep the camera position towards the center of the feeder.
-		camPos = pixel.Lerp(camPos, context.Seed().center, 1)
-		cam := pixel.IM.Moved(camPos.Scaled(-1))
-		canvas.SetMatrix(cam)
+			birds = simulate(birds, elapsed)
 
-		for _, bird := range birds {
-			// Update the physics and animation.
-			bird.update(elapsed)
-			bird.animation.update(elapsed, bird)
+			// Keep the camera position towards the center of the feeder.
+			camPos = pixel.Lerp(camPos, context.Seed().center, 1)
+			cam = pixel.IM.Moved(camPos.Scaled(-1))
 		}
+		canvas.SetMatrix(cam)
 
 		// Clear the scene to be re-drawn.
 		canvas.Clear(colornames.Black)
 		globalImd.Clear()
-		if backgroundImd != nil {
-			backgroundImd.Clear()
-		}
-		if seedsImd != nil {
-			seedsImd.Clear()
+		weatherImd.Clear()
+
+		// The background only needs drawing again when it changes with the time of day (or season, or context), or the
+		// window is resized.
+		if picture := resolveBackgroundPicture(); picture != backgroundPicture || backgroundBounds != layout.canvasBounds {
+			backgroundPicture = picture
+			backgroundBounds = layout.canvasBounds
+			backgroundImd = imdraw.New(backgroundPicture)
+			drawBackground(backgroundImd, backgroundPicture)
 		}
 
-		for _, bird := range birds {
-			bird.animation.imd.Clear()
+		// Draw the seed, which goes down as it's eaten. The picture can change based on time.
+		if picture := resolveSeedsPicture(); picture != seedsPicture {
+			seedsPicture = picture
+			seedsImd = imdraw.New(seedsPicture)
 		}
-
-		// Reset the background as it could change based on time.
-		backgroundPicture := resolveBackgroundPicture()
-		backgroundImd = imdraw.New(backgroundPicture)
-		backgroundSprite := pixel.NewSprite(nil, pixel.Rect{})
-		backgroundSprite.Set(backgroundPicture, pixel.Rect{Min: pixel.Vec{X: 0, Y: 0}, Max: pixel.Vec{X: 1600, Y: 900}})
-
-		// Draw the background sprite. Hardcoding the pixel calculations, cry about it if you want.
-		backgroundSprite.Draw(backgroundImd, pixel.IM.ScaledXY(pixel.ZV, pixel.V(1.25, 1.25)).Moved(pixel.Vec{X: 200, Y: 112.5}))
-
-		// Draw the seed. Can change based on time so always re-set.
-		seedsPicture := resolveSeedsPicture()
-		seedsImd = imdraw.New(seedsPicture)
+		seedsImd.Clear()
 		context.Seed().draw(seedsImd, birds, seedsPicture)
 
-		// Draw stationary birds first.
+		// Draw stationary birds first, then the flying birds, all from the one atlas.
+		birdBatch := content.atlas.begin()
 		for _, bird := range birds {
 			if !bird.entering && !bird.exiting {
-				bird.animation.draw(bird.physics)
+				bird.animation.draw(bird.physics, birdBatch, content.atlas.picture)
 			}
 		}
 
-		// ...then draw the flying birds.
 		for _, bird := range birds {
 			if bird.entering || bird.exiting {
-				bird.animation.draw(bird.physics)
+				bird.animation.draw(bird.physics, birdBatch, content.atlas.picture)
 			}
 		}
 
@@ -162,20 +205,32 @@ func run() {
 		// Draw to the canvas.
 		seedsImd.Draw(canvas)
 		backgroundImd.Draw(canvas)
+		drawDecorations(canvas, backgroundPicture)
 		globalImd.Draw(canvas)
 
 		// Draw birds to the canvas now.
-		for _, bird := range birds {
-			if !bird.entering && !bird.exiting {
-				bird.animation.imd.Draw(canvas)
+		birdBatch.Draw(canvas)
+
+		// Draw the weather over everything else in the scene, seed flying about included.
+		seedParticles.draw(weatherImd)
+		weather.draw(weatherImd)
+		weatherImd.Draw(canvas)
+
+		// Captions and the song visualisation go over the top of the scene (but under the menu). Photos are taken
+		// without any of it, just the scene, with the controls drawn over it afterwards.
+		if photo.active {
+			if win.JustPressed(pixelgl.KeySpace) {
+				photo.take(canvas, birds)
 			}
-		}
 
-		// ...then draw the flying birds to the canvas.
-		for _, bird := range birds {
-			if bird.entering || bird.exiting {
-				bird.animation.imd.Draw(canvas)
-			}
+			photo.draw(canvas)
+		} else {
+			captions.update()
+			captions.draw(canvas)
+			visualiser.update()
+			visualiser.draw(canvas)
+			economy.draw(canvas)
+			achievements.draw(canvas)
 		}
 
 		// Draw the pause menu when open.
@@ -185,15 +240,51 @@ func run() {
 
 		// Stretch the canvas to the window.
 		win.Clear(colornames.White)
-		win.SetMatrix(pixel.IM.Scaled(pixel.ZV,
-			math.Min(
-				win.Bounds().W()/canvas.Bounds().W(),
-				win.Bounds().H()/canvas.Bounds().H(),
-			),
-		).Moved(win.Bounds().Center()))
-		canvas.Draw(win, pixel.IM.Moved(canvas.Bounds().Center()))
+		presentCanvas(win, canvas)
 		win.Update()
+
+		// Ease off while nothing is happening, when running as a display.
+		if displayModeEnabled() && !pauseMenu.open && !photo.active {
+			throttleIdleFrame(last, birds)
+		}
+	}
+
+	// Keep anything earned since the last save.
+	saveSettings()
+	ambience.close()
+}
+
+// Move everything in the backyard along by a frame, returning the birds still in it.
+func simulate(birds []*bird, elapsed float64) []*bird {
+	// Refill the seed automatically, if the refill policy does that.
+	refiller.update()
+
+	// Determine new birds / remove birds.
+	birds = resolveBirds(birds)
+
+	// Move the weather along.
+	weather.update(elapsed)
+
+	// Birds out of sight come and go, singing as the time of day has them, with calls from further off.
+	chorus.update()
+	soundscape.update()
+
+	for _, bird := range birds {
+		// Update the physics and animation.
+		bird.update(elapsed)
+		bird.animation.update(elapsed, bird)
 	}
+
+	// Birds of a feather answer each other's songs.
+	songs.update(birds)
+
+	// Earn anything the scene as it stands is worth.
+	achievements.update(birds, elapsed)
+
+	// Move any seed the birds have flicked about.
+	seedParticles.update(elapsed)
+
+	return birds
 }
 
 func initializeFeederContext(win *pixelgl.Window, canvas *pixelgl.Canvas, imd *imdraw.IMDraw) {
@@ -201,8 +292,10 @@ func initializeFeederContext(win *pixelgl.Window, canvas *pixelgl.Canvas, imd *i
 	context = feederContextMappings[standardHouseFeederName]
 	context.Initialize()
 
-	// Show feeder context selection menu.
-	showMenu(win, canvas, imd, &[]*bird{})
+	// Show feeder context selection menu, unless running as a display (where nobody is around to pick).
+	if !displayModeEnabled() {
+		showMenu(win, canvas, imd, &[]*bird{})
+	}
 }
 
 func resolveBirds(birds []*bird) []*bird {
@@ -215,29 +308,33 @@ func resolveBirds(birds []*bird) []*bird {
 	}
 
 	// Add any new birds.
-	if success, newBird := resolveNewBird(birds); success {
-		birds = append(birds, newBird)
+	if success, newBirds := resolveNewBirds(birds); success {
+		birds = append(birds, newBirds...)
 		setNextBirdSpawnTime()
+
+		for _, newBird := range newBirds {
+			simulationEvents.publish(simulationEvent{kind: birdArrived, species: newBird.species, bird: newBird})
+		}
 	}
 
 	// Return the new birds list.
 	return birds
 }
 
-func resolveNewBird(birds []*bird) (bool, *bird) {
+func resolveNewBirds(birds []*bird) (bool, []*bird) {
 	// Don't span a bird until the next bird spawn time has been achieved.
 	if time.Now().Before(nextBirdSpawnTime) {
-		return false, &bird{}
+		return false, nil
 	}
 
-	// Create a bird given the remaining space.
-	return birdFactory()
+	// Create a bird (or a flock of them) given the remaining space.
+	return birdFactory(birds)
 }
 
 func resolveNewSpawnLengthInSeconds() (int, error) {
 	// Enumerate all spawn length category choices.
 	choices := []wr.Choice{}
-	for category, likelihood := range context.TimeLikelihoods() {
+	for category, likelihood := range weather.timeLikelihoods(context.TimeLikelihoods()) {
 		choices = append(choices, wr.Choice{Item: category, Weight: likelihood})
 	}
 
@@ -272,11 +369,14 @@ func resolveNewSpawnLengthInSeconds() (int, error) {
 	return 0, errors.New("Invalid spawn length category selected")
 }
 
-func birdFactory() (bool, *bird) {
-	// Enumerate all bird choices.
-	choices := []wr.Choice{}
-	for bird, likelihood := range context.BirdLikelihoods() {
-		choices = append(choices, wr.Choice{Item: bird, Weight: likelihood})
+func birdFactory(birds []*bird) (bool, []*bird) {
+	// Enumerate all bird choices for this region, weighted by what time of year it is.
+	choices := seasonalBirdChoices(time.Now())
+
+	// No birds around at this time of year.
+	if len(choices) == 0 {
+		setNextBirdSpawnTime()
+		return false, nil
 	}
 
 	// Initialize a weighted probability bird chooser.
@@ -284,26 +384,50 @@ func birdFactory() (bool, *bird) {
 
 	// Pick a random bird.
 	birdSpeciesPick := chooser.Pick().(species)
-	birdSpeciesPick.Initialize()
 
-	// Pick a random (available) perch.
-	perchFound, newPerch := getRandomPerch(birdSpeciesPick)
+	// Pick how many of this species are arriving together, and reserve a perch for as many of them as possible.
+	flockSize := resolveFlockSize(birdSpeciesPick)
+	newPerches := getRandomPerches(context.Perches(), birdSpeciesPick, flockSize)
 
-	// Determine if it's possible to fit this bird on the feeder.
-	if !perchFound {
+	// A dominant bird can chase a smaller one off its perch when the feeder is full.
+	if len(newPerches) == 0 {
+		if displaced, takenPerch := displaceBird(birds, birdSpeciesPick); displaced {
+			newPerches = append(newPerches, takenPerch)
+		}
+	}
+
+	// Birds that can't fit on the feeder land nearby and wait for a perch to free up. Any beyond that simply don't come.
+	waitingPerches := getRandomPerches(context.WaitingPerches(), birdSpeciesPick, flockSize-len(newPerches))
+	newPerches = append(newPerches, waitingPerches...)
+
+	// Determine if it's possible to fit this bird anywhere.
+	if len(newPerches) == 0 {
 		// Set back bird spawn time when all spots are filled. NOTE: Potentially lower this to a set amount of delay.
 		setNextBirdSpawnTime()
-		return false, &bird{}
+		return false, nil
 	}
 
-	// Load the sprite/animation.
-	animationSheet, birdAnimations, err := loadAnimationSheet(birdSpeciesPick.Animation(), animationMappingsFile, standardSpriteWidth)
+	// Load the sprite/animation, if this species hasn't been loaded already.
+	birdAnimations, err := content.birdAnimations(birdSpeciesPick.Animation())
 	if err != nil {
 		panic(err)
 	}
 
-	// Create the bird, along with animations and new default flight script.
-	return true, newBird(defaultBirdFrameRate, birdSpeciesPick, birdAnimations, animationSheet, newPerch, imdraw.New(animationSheet))
+	// Create the birds, along with animations and new default flight scripts.
+	newFlock := &flock{}
+	newBirds := []*bird{}
+	for _, newPerch := range newPerches {
+		member := newBird(birdSpeciesPick, birdAnimations, newPerch)
+
+		// Lone birds don't need any flocking behaviour.
+		if len(newPerches) > 1 {
+			newFlock.add(member)
+		}
+
+		newBirds = append(newBirds, member)
+	}
+
+	return true, newBirds
 }
 
 func removeBirds(birds []*bird) []*bird {
@@ -317,6 +441,7 @@ func removeBirds(birds []*bird) []*bird {
 		// Mark the fully removed birds to be deleted.
 		if bird.removed {
 			indexesForDeletion = append(indexesForDeletion, index)
+			simulationEvents.publish(simulationEvent{kind: birdDeparted, species: bird.species, bird: bird, visitLength: time.Since(bird.entranceTime)})
 		}
 	}
 
@@ -332,6 +457,34 @@ func birdShouldExit(bird *bird) bool {
 	return !bird.entering && !bird.eating && !bird.removed && !bird.exiting && !bird.singing && time.Now().After(bird.removalTime)
 }
 
+// Put back everything that was due by how long the scene stood still, so nothing falls due the moment it moves again.
+func postponeSimulation(birds []*bird, length time.Duration) {
+	nextBirdSpawnTime = postponed(nextBirdSpawnTime, length)
+
+	for _, bird := range birds {
+		bird.postpone(length)
+	}
+
+	songs.postpone(length)
+	chorus.postpone(length)
+	soundscape.nextCallTime = postponed(soundscape.nextCallTime, length)
+	weather.nextChangeTime = postponed(weather.nextChangeTime, length)
+}
+
+// Every species around in the current region on the given date, weighted by how likely it is to show up.
+func seasonalBirdChoices(date time.Time) []wr.Choice {
+	choices := []wr.Choice{}
+	for bird, likelihood := range regionalBirdLikelihoods() {
+		// The seed in the feeder draws some species in more than others.
+		weight := uint(math.Round(float64(seasonalLikelihood(bird, likelihood, date)) * economy.speciesMultiplier(bird)))
+		if weight > 0 {
+			choices = append(choices, wr.Choice{Item: bird, Weight: weight})
+		}
+	}
+
+	return choices
+}
+
 func setNextBirdSpawnTime() {
 	// Establish when the first bird will be spawned.
 	newBirdSpawnLength, err := resolveNewSpawnLengthInSeconds()
@@ -339,22 +492,12 @@ func setNextBirdSpawnTime() {
 		newBirdSpawnLength = defaultSpawnLength
 	}
 
-	nextBirdSpawnTime = time.Now().Add(time.Second * time.Duration(newBirdSpawnLength))
+	// A brush pile brings them by a little more often.
+	nextBirdSpawnTime = time.Now().Add(time.Duration(float64(time.Second) * float64(newBirdSpawnLength) * economy.spawnGapMultiplier()))
 }
 
 func getRandomPerch(species species) (bool, *perch) {
-	availablePerches := []*perch{}
-
-	// Enumerate all available (unoccupied) perches.
-	for _, perch := range context.Perches() {
-		perchWidth := perch.X.max - perch.X.min
-		perchHeight := perch.Y.max - perch.Y.min
-
-		// Perch must be unoccupied and spacious enough to accomodate this species.
-		if !perch.occupied && perchWidth >= species.Width() && perchHeight >= species.Height() {
-			availablePerches = append(availablePerches, perch)
-		}
-	}
+	availablePerches := getAvailablePerches(context.Perches(), species)
 
 	// No perches found, return unsuccessful.
 	if len(availablePerches) == 0 {
@@ -371,15 +514,54 @@ func getRandomPerch(species species) (bool, *perch) {
 	return true, availablePerches[perchIndex]
 }
 
+// Pick up to 'count' distinct available perches at random.
+func getRandomPerches(perches []*perch, species species, count int) []*perch {
+	availablePerches := getAvailablePerches(perches, species)
+
+	// Shuffle so that each perch is equally likely to be picked.
+	rand.Shuffle(len(availablePerches), func(i, j int) {
+		availablePerches[i], availablePerches[j] = availablePerches[j], availablePerches[i]
+	})
+
+	if count < len(availablePerches) {
+		return availablePerches[:count]
+	}
+
+	return availablePerches
+}
+
+func getAvailablePerches(perches []*perch, species species) []*perch {
+	availablePerches := []*perch{}
+
+	// Enumerate all available (unoccupied) perches.
+	for _, perch := range perches {
+		perchWidth := perch.X.max - perch.X.min
+		perchHeight := perch.Y.max - perch.Y.min
+
+		// Perch must be unoccupied and spacious enough to accomodate this species.
+		if !perch.occupied && perchWidth >= species.Width() && perchHeight >= species.Height() {
+			availablePerches = append(availablePerches, perch)
+		}
+	}
+
+	return availablePerches
+}
+
 func showMenu(win *pixelgl.Window, canvas *pixelgl.Canvas, imd *imdraw.IMDraw, currentBirds *[]*bird) {
 	menu := &mainMenu{}
 	menu.ShowMainMenu(win, canvas, imd, currentBirds)
 }
 
 func bufferContextSounds() {
-	// Buffer all sounds.
+	// Buffer all sounds. A sound that can't be loaded is left out (and stays silent) rather than stopping everything.
 	for sound, path := range context.Sounds() {
-		soundBuffers[sound] = bufferSound(path)
+		buffer, err := bufferSound(path)
+		if err != nil {
+			fmt.Println("warning: could not load sound " + sound + " from " + path + ": " + err.Error())
+			continue
+		}
+
+		soundBuffers[sound] = buffer
 	}
 }
 
@@ -387,35 +569,39 @@ func initializeSounds() {
 	// Reset sound buffers.
 	soundBuffers = make(map[string]*beep.Buffer)
 
-	// Stop playing current sounds.
-	speaker.Clear()
-	speaker.Init(44100, int(time.Second/60*time.Duration(44100)/time.Second))
+	// Stop playing current sounds. The ambience carries on until the new one crossfades in.
+	mixer.start()
+	mixer.clear(songsBus)
 
 	// Initialize the new sounds.
 	bufferContextSounds()
+	bufferSoundscapeSounds()
 
-	// Terminate any current background sound through the current channel for it.
-	if len(backgroundSoundControllers) != 0 {
-		close(backgroundSoundControllers[backgroundSoundControllerIndex])
-		backgroundSoundControllerIndex++
-	}
+	// Birds singing out of sight belong to the old feeder (or region), so they move on.
+	chorus.clear()
 
-	// Play background sound. Include terminator channel.
-	backgroundSoundController := make(chan bool)
-	backgroundSoundControllers = append(backgroundSoundControllers, backgroundSoundController)
-	go playLoopingSound(soundBuffers["Background"], backgroundSoundControllers[backgroundSoundControllerIndex])
+	// Play the background sound.
+	ambience.crossfadeTo(soundBuffers["Background"], mixerFadeLength)
 }
 
 func enableSounds() {
 	soundDisabled = false
+	mixer.fadeIn()
 }
 
+// The ambience keeps looping silently underneath the master volume, so it picks up where it was when unmuted.
 func disableSounds() {
-	speaker.Clear()
 	soundDisabled = true
+	mixer.clear(songsBus)
+	mixer.setMasterVolume(mixer.masterVolume)
 }
 
 func resolveBackgroundPicture() pixel.Picture {
+	// Prefer a seasonal variant (snow, autumn leaves, etc.) when the context has one.
+	if seasonalBackground, found := context.SeasonalBackgrounds()[getSeason(time.Now())][getTimeOfDay()]; found {
+		return seasonalBackground
+	}
+
 	return context.Backgrounds()[getTimeOfDay()]
 }
 
@@ -466,5 +652,6 @@ func needsUpdate() bool {
 }
 
 func main() {
+	flag.Parse()
 	pixelgl.Run(run)
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
@@ -17,7 +18,7 @@ type mainMenu struct {
 }
 
 func (menu *mainMenu) NumOptionIndexes() int {
-	additionalOptions := 1
+	additionalOptions := 2
 	return (len(feederContexts) - 1) + additionalOptions
 }
 
@@ -34,8 +35,8 @@ func (menu *mainMenu) ShowMainMenu(win *pixelgl.Window, canvas *pixelgl.Canvas,
 		}
 	}
 
-	xValue := canvas.Bounds().Min.X + 100
-	yValue := canvas.Bounds().Max.Y - 100
+	xValue := layout.visibleBounds.Min.X + 100
+	yValue := layout.visibleBounds.Max.Y - 100
 	atlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
 	menu.text = text.New(pixel.Vec{X: xValue, Y: yValue}, atlas)
 	menu.PrintMenuText(selectedOptionNumber, context.Name())
@@ -60,13 +61,7 @@ func (menu *mainMenu) ShowMainMenu(win *pixelgl.Window, canvas *pixelgl.Canvas,
 
 		canvas.Clear(colornames.Rosybrown)
 		menu.text.Draw(canvas, pixel.IM.Scaled(menu.text.Orig, 3))
-		win.SetMatrix(pixel.IM.Scaled(pixel.ZV,
-			math.Min(
-				win.Bounds().W()/canvas.Bounds().W(),
-				win.Bounds().H()/canvas.Bounds().H(),
-			),
-		).Moved(win.Bounds().Center()))
-		canvas.Draw(win, pixel.IM.Moved(canvas.Bounds().Center()))
+		presentCanvas(win, canvas)
 		win.Update()
 
 		// Leave the menu when enter or escape is pressed.
@@ -76,7 +71,8 @@ func (menu *mainMenu) ShowMainMenu(win *pixelgl.Window, canvas *pixelgl.Canvas,
 				context = feederContextMappings[feederContexts[selectedOptionNumber]]
 				context.Initialize()
 				*currentBirds = nil
-			} else if selectedOptionNumber == menu.NumOptionIndexes() {
+				waitingBirds = nil
+			} else if selectedOptionNumber == len(feederContexts) {
 				if soundDisabled {
 					enableSounds()
 				} else {
@@ -84,6 +80,12 @@ func (menu *mainMenu) ShowMainMenu(win *pixelgl.Window, canvas *pixelgl.Canvas,
 				}
 
 				// Don't close the menu if muting/unmuting.
+				saveSettings()
+				continue
+			} else if selectedOptionNumber == len(feederContexts)+1 {
+				// Don't close the menu when switching regions either.
+				cycleRegion()
+				saveSettings()
 				continue
 			}
 
@@ -102,21 +104,15 @@ func (menu *mainMenu) ShowMainMenu(win *pixelgl.Window, canvas *pixelgl.Canvas,
 func (menu *mainMenu) ShowLoadingScreen(win *pixelgl.Window, canvas *pixelgl.Canvas, imd *imdraw.IMDraw) {
 	imd.Clear()
 
-	xValue := canvas.Bounds().Min.X + 100
-	yValue := canvas.Bounds().Max.Y - 100
+	xValue := layout.visibleBounds.Min.X + 100
+	yValue := layout.visibleBounds.Max.Y - 100
 	atlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
 	menu.text = text.New(pixel.Vec{X: xValue, Y: yValue}, atlas)
 	menu.PrintLoadingText()
 
 	canvas.Clear(colornames.Rosybrown)
 	menu.text.Draw(canvas, pixel.IM.Scaled(menu.text.Orig, 3))
-	win.SetMatrix(pixel.IM.Scaled(pixel.ZV,
-		math.Min(
-			win.Bounds().W()/canvas.Bounds().W(),
-			win.Bounds().H()/canvas.Bounds().H(),
-		),
-	).Moved(win.Bounds().Center()))
-	canvas.Draw(win, pixel.IM.Moved(canvas.Bounds().Center()))
+	presentCanvas(win, canvas)
 	win.Update()
 }
 
@@ -165,6 +161,15 @@ func (menu *mainMenu) PrintMenuText(selectedContextNumber int, currentContextNam
 
 	fmt.Fprintln(menu.text, soundOption)
 
+	// Print the region selector.
+	if selectedContextNumber == len(feederContexts)+1 {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, "Region: "+regionNames[currentRegion])
+
 	menu.text.Color = colornames.White
 	fmt.Fprintln(menu.text, "------------------------------")
 
@@ -200,13 +205,43 @@ type pauseMenu struct {
 	justOpenedMenu       bool
 	upperYBound          float64
 	lowerYBound          float64
+
+	// Which page of the menu is showing, and the selected option on the audio, economy and gallery pages.
+	page                        pauseMenuPage
+	selectedAudioOptionNumber   int
+	selectedEconomyOptionNumber int
+	selectedGalleryOptionNumber int
+
+	// The photos in the gallery, read when it's opened.
+	galleryPhotos []galleryPhoto
 }
 
+type pauseMenuPage int
+
+const (
+	mainPage pauseMenuPage = iota
+	audioPage
+	economyPage
+	achievementsPage
+	galleryPage
+)
+
 func (menu *pauseMenu) NumOptionIndexes() int {
-	additionalOptions := 1
+	additionalOptions := 9
+
+	// The seed bag can only be restocked when there is one.
+	if refiller.policy == seedBagRefill {
+		additionalOptions++
+	}
+
 	return (len(feederContexts) - 1) + additionalOptions
 }
 
+func (menu *pauseMenu) NumAudioOptionIndexes() int {
+	// Master volume, each bus, then back.
+	return len(audioBuses) + 1
+}
+
 func (menu *pauseMenu) Show(canvas *pixelgl.Canvas) {
 	menu.text.Draw(canvas, pixel.IM.Scaled(menu.text.Orig, 2))
 }
@@ -222,13 +257,7 @@ func (menu *pauseMenu) ShowLoadingScreen(win *pixelgl.Window, imd *imdraw.IMDraw
 
 	// Stretch the canvas to the window.
 	win.Clear(colornames.White)
-	win.SetMatrix(pixel.IM.Scaled(pixel.ZV,
-		math.Min(
-			win.Bounds().W()/canvas.Bounds().W(),
-			win.Bounds().H()/canvas.Bounds().H(),
-		),
-	).Moved(win.Bounds().Center()))
-	canvas.Draw(win, pixel.IM.Moved(canvas.Bounds().Center()))
+	presentCanvas(win, canvas)
 	win.Update()
 }
 
@@ -249,6 +278,24 @@ func (menu *pauseMenu) Render(win *pixelgl.Window, imd *imdraw.IMDraw, canvas *p
 
 	imd.Rectangle(0)
 
+	// The audio settings have a page of their own.
+	if menu.page == audioPage {
+		menu.RenderAudioPage(win)
+		return
+	}
+
+	// ...as do the seed economy, the achievements and the photo gallery.
+	if menu.page == economyPage {
+		menu.RenderEconomyPage(win)
+		return
+	} else if menu.page == achievementsPage {
+		menu.RenderAchievementsPage(win)
+		return
+	} else if menu.page == galleryPage {
+		menu.RenderGalleryPage(win)
+		return
+	}
+
 	if win.JustPressed(pixelgl.KeyDown) {
 		if menu.selectedOptionNumber == menu.NumOptionIndexes() {
 			menu.selectedOptionNumber = 0
@@ -274,14 +321,58 @@ func (menu *pauseMenu) Render(win *pixelgl.Window, imd *imdraw.IMDraw, canvas *p
 			context = feederContextMappings[feederContexts[menu.selectedOptionNumber]]
 			context.Initialize()
 			*currentBirds = nil
+			waitingBirds = nil
 			initializeSounds()
-		} else if menu.selectedOptionNumber == menu.NumOptionIndexes() {
+		} else if menu.selectedOptionNumber == len(feederContexts) {
 			if soundDisabled {
 				enableSounds()
 			} else {
 				disableSounds()
 			}
 
+			saveSettings()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+1 {
+			// The new region may have its own songs.
+			cycleRegion()
+			initializeSounds()
+			saveSettings()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+2 {
+			menu.page = audioPage
+			menu.selectedAudioOptionNumber = 0
+			menu.PrintAudioMenuText()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+3 {
+			captionsEnabled = !captionsEnabled
+			saveSettings()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+4 {
+			songVisualisationEnabled = !songVisualisationEnabled
+			saveSettings()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+5 {
+			cycleRefillPolicy()
+			saveSettings()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+6 {
+			menu.page = economyPage
+			menu.selectedEconomyOptionNumber = 0
+			menu.PrintEconomyMenuText()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+7 {
+			menu.page = achievementsPage
+			menu.PrintAchievementsMenuText()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+8 {
+			menu.page = galleryPage
+			menu.selectedGalleryOptionNumber = 0
+			menu.galleryPhotos = listPhotos()
+			menu.PrintGalleryMenuText()
+			return
+		} else if menu.selectedOptionNumber == len(feederContexts)+9 {
+			refiller.restock()
+			saveSettings()
 			return
 		}
 
@@ -370,6 +461,48 @@ func (menu *pauseMenu) PrintMenuText(selectedContextNumber int, currentContextNa
 
 	fmt.Fprintln(menu.text, soundOption)
 
+	// Print the region selector.
+	if selectedContextNumber == len(feederContexts)+1 {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, "Region: "+regionNames[currentRegion])
+
+	// Print the audio settings button.
+	if selectedContextNumber == len(feederContexts)+2 {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, "Audio settings")
+
+	// Print the accessibility toggles.
+	menu.printToggleOption(len(feederContexts)+3, selectedContextNumber, "Captions", captionsEnabled)
+	menu.printToggleOption(len(feederContexts)+4, selectedContextNumber, "Song visualisation", songVisualisationEnabled)
+
+	// Print the refill policy, and the option to restock the seed bag when there is one.
+	menu.printOption(len(feederContexts)+5, selectedContextNumber, refiller.description())
+
+	// Print the seed economy button.
+	economyOption := "Seed economy: off"
+	if economy.enabled {
+		economyOption = fmt.Sprintf("Seed economy: %d coins", economy.coins)
+	}
+	menu.printOption(len(feederContexts)+6, selectedContextNumber, economyOption)
+	menu.printOption(len(feederContexts)+7, selectedContextNumber, fmt.Sprintf("Achievements: %d of %d", len(achievements.earned), len(allAchievements())))
+	menu.printOption(len(feederContexts)+8, selectedContextNumber, "Photo gallery")
+
+	if refiller.policy == seedBagRefill {
+		restockOption := "Restock seed bag"
+		if economy.enabled {
+			restockOption += fmt.Sprintf(" (%d coins)", seedBagCost)
+		}
+		menu.printOption(len(feederContexts)+9, selectedContextNumber, restockOption)
+	}
+
 	menu.text.Color = colornames.White
 	fmt.Fprintln(menu.text, "------------------------------")
 
@@ -379,3 +512,347 @@ func (menu *pauseMenu) PrintMenuText(selectedContextNumber int, currentContextNa
 	menu.text.Color = colornames.White
 	fmt.Fprintln(menu.text, "------------------------------")
 }
+
+func (menu *pauseMenu) printToggleOption(optionNumber, selectedOptionNumber int, name string, enabled bool) {
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+
+	menu.printOption(optionNumber, selectedOptionNumber, name+": "+state)
+}
+
+func (menu *pauseMenu) printOption(optionNumber, selectedOptionNumber int, label string) {
+	if selectedOptionNumber == optionNumber {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, label)
+}
+
+func (menu *pauseMenu) RenderAudioPage(win *pixelgl.Window) {
+	if win.JustPressed(pixelgl.KeyDown) {
+		if menu.selectedAudioOptionNumber == menu.NumAudioOptionIndexes() {
+			menu.selectedAudioOptionNumber = 0
+		} else {
+			menu.selectedAudioOptionNumber++
+		}
+	} else if win.JustPressed(pixelgl.KeyUp) {
+		if menu.selectedAudioOptionNumber == 0 {
+			menu.selectedAudioOptionNumber = menu.NumAudioOptionIndexes()
+		} else {
+			menu.selectedAudioOptionNumber--
+		}
+	}
+
+	// Left and right change the volume, enter mutes/unmutes.
+	volumeChange := 0.0
+	if win.JustPressed(pixelgl.KeyRight) {
+		volumeChange = volumeStep
+	} else if win.JustPressed(pixelgl.KeyLeft) {
+		volumeChange = -volumeStep
+	}
+
+	if menu.selectedAudioOptionNumber == 0 {
+		// Master volume.
+		if volumeChange != 0 {
+			mixer.setMasterVolume(mixer.masterVolume + volumeChange)
+			saveSettings()
+		} else if win.JustPressed(pixelgl.KeyEnter) {
+			if soundDisabled {
+				enableSounds()
+			} else {
+				disableSounds()
+			}
+
+			saveSettings()
+		}
+	} else if menu.selectedAudioOptionNumber <= len(audioBuses) {
+		// A single bus.
+		bus := audioBuses[menu.selectedAudioOptionNumber-1]
+
+		if volumeChange != 0 {
+			mixer.setVolume(bus, mixer.buses[bus].volume+volumeChange)
+			saveSettings()
+		} else if win.JustPressed(pixelgl.KeyEnter) {
+			mixer.setMuted(bus, !mixer.buses[bus].muted)
+			saveSettings()
+		}
+	} else if win.JustPressed(pixelgl.KeyEnter) {
+		menu.page = mainPage
+	}
+
+	// Escape goes back to the main page rather than closing the menu.
+	if win.JustPressed(pixelgl.KeyEscape) {
+		menu.page = mainPage
+	}
+
+	if menu.page == mainPage {
+		menu.PrintMenuText(menu.selectedOptionNumber, context.Name())
+	} else {
+		menu.PrintAudioMenuText()
+	}
+}
+
+func (menu *pauseMenu) PrintAudioMenuText() {
+	menu.text.Clear()
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "Audio settings")
+	fmt.Fprintln(menu.text, "------------------------------")
+
+	menu.text.Color = colornames.Pink
+	fmt.Fprintln(menu.text, "Left/right to change the volume, enter to mute/unmute.")
+	fmt.Fprintln(menu.text) // New line.
+
+	// Master volume, then each bus.
+	menu.printAudioOption(0, "Master", mixer.masterVolume, soundDisabled)
+	for index, bus := range audioBuses {
+		menu.printAudioOption(index+1, audioBusNames[bus], mixer.buses[bus].volume, mixer.buses[bus].muted)
+	}
+
+	fmt.Fprintln(menu.text) // New line.
+
+	if menu.selectedAudioOptionNumber == menu.NumAudioOptionIndexes() {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, "Back")
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "------------------------------")
+}
+
+func (menu *pauseMenu) printAudioOption(optionNumber int, name string, volume float64, muted bool) {
+	if menu.selectedAudioOptionNumber == optionNumber {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	option := fmt.Sprintf("%s: %d%%", name, int(math.Round(volume*100)))
+	if muted {
+		option += " (muted)"
+	}
+
+	fmt.Fprintln(menu.text, option)
+}
+
+func (menu *pauseMenu) NumEconomyOptionIndexes() int {
+	// The on/off toggle, everything in the shop, then back.
+	return len(shopItems) + 1
+}
+
+func (menu *pauseMenu) RenderEconomyPage(win *pixelgl.Window) {
+	if win.JustPressed(pixelgl.KeyDown) {
+		if menu.selectedEconomyOptionNumber == menu.NumEconomyOptionIndexes() {
+			menu.selectedEconomyOptionNumber = 0
+		} else {
+			menu.selectedEconomyOptionNumber++
+		}
+	} else if win.JustPressed(pixelgl.KeyUp) {
+		if menu.selectedEconomyOptionNumber == 0 {
+			menu.selectedEconomyOptionNumber = menu.NumEconomyOptionIndexes()
+		} else {
+			menu.selectedEconomyOptionNumber--
+		}
+	}
+
+	if win.JustPressed(pixelgl.KeyEnter) {
+		if menu.selectedEconomyOptionNumber == 0 {
+			economy.enabled = !economy.enabled
+			saveSettings()
+		} else if menu.selectedEconomyOptionNumber <= len(shopItems) {
+			// Nothing can be bought while the economy is off.
+			if economy.enabled && economy.buy(shopItems[menu.selectedEconomyOptionNumber-1]) {
+				saveSettings()
+			}
+		} else {
+			menu.page = mainPage
+		}
+	}
+
+	// Escape goes back to the main page rather than closing the menu.
+	if win.JustPressed(pixelgl.KeyEscape) {
+		menu.page = mainPage
+	}
+
+	if menu.page == mainPage {
+		menu.PrintMenuText(menu.selectedOptionNumber, context.Name())
+	} else {
+		menu.PrintEconomyMenuText()
+	}
+}
+
+func (menu *pauseMenu) PrintEconomyMenuText() {
+	menu.text.Clear()
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "Seed economy")
+	fmt.Fprintln(menu.text, "------------------------------")
+
+	menu.text.Color = colornames.Pink
+	fmt.Fprintln(menu.text, "Earn coins by watching birds, spend them on seed and more.")
+	fmt.Fprintln(menu.text) // New line.
+
+	state := "off"
+	if economy.enabled {
+		state = "on"
+	}
+
+	menu.printEconomyOption(0, fmt.Sprintf("Seed economy: %s (%d coins)", state, economy.coins))
+	fmt.Fprintln(menu.text) // New line.
+
+	for index, item := range shopItems {
+		status := fmt.Sprintf("%d coins", item.cost)
+		switch {
+		case item.kind == seedUnlock && economy.seedType == item.name:
+			status = "in the feeder"
+		case economy.owns(item):
+			status = "owned"
+		}
+
+		menu.printEconomyOption(index+1, fmt.Sprintf("%s: %s - %s (%s)", unlockKindNames[item.kind], item.name, item.description, status))
+	}
+
+	fmt.Fprintln(menu.text) // New line.
+	menu.printEconomyOption(menu.NumEconomyOptionIndexes(), "Back")
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "------------------------------")
+}
+
+func (menu *pauseMenu) printEconomyOption(optionNumber int, label string) {
+	if menu.selectedEconomyOptionNumber == optionNumber {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, label)
+}
+
+// The achievements page only lists them, so there's nothing to select but back.
+func (menu *pauseMenu) RenderAchievementsPage(win *pixelgl.Window) {
+	if win.JustPressed(pixelgl.KeyEnter) || win.JustPressed(pixelgl.KeyEscape) {
+		menu.page = mainPage
+		menu.PrintMenuText(menu.selectedOptionNumber, context.Name())
+		return
+	}
+
+	menu.PrintAchievementsMenuText()
+}
+
+func (menu *pauseMenu) PrintAchievementsMenuText() {
+	menu.text.Clear()
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "Achievements")
+	fmt.Fprintln(menu.text, "------------------------------")
+
+	for _, achievement := range allAchievements() {
+		if earned, found := achievements.earned[achievement.id]; found {
+			menu.text.Color = colornames.Gold
+			fmt.Fprintf(menu.text, "[x] %s: %s (%s)\n", achievement.name, achievement.description, earned.Format("Jan 2, 2006"))
+		} else {
+			menu.text.Color = colornames.Lightgray
+			fmt.Fprintf(menu.text, "[ ] %s: %s\n", achievement.name, achievement.description)
+		}
+	}
+
+	fmt.Fprintln(menu.text) // New line.
+	menu.text.Color = colornames.Red
+	fmt.Fprintln(menu.text, "Back")
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "------------------------------")
+}
+
+func (menu *pauseMenu) NumGalleryOptionIndexes() int {
+	// Each photo, then back.
+	return len(menu.galleryPhotos)
+}
+
+// The gallery lists the photos taken, galleryPageLength at a time, scrolling to follow the selection.
+func (menu *pauseMenu) RenderGalleryPage(win *pixelgl.Window) {
+	if win.JustPressed(pixelgl.KeyDown) {
+		if menu.selectedGalleryOptionNumber == menu.NumGalleryOptionIndexes() {
+			menu.selectedGalleryOptionNumber = 0
+		} else {
+			menu.selectedGalleryOptionNumber++
+		}
+	} else if win.JustPressed(pixelgl.KeyUp) {
+		if menu.selectedGalleryOptionNumber == 0 {
+			menu.selectedGalleryOptionNumber = menu.NumGalleryOptionIndexes()
+		} else {
+			menu.selectedGalleryOptionNumber--
+		}
+	}
+
+	// Only back does anything; the photos themselves are there to look through.
+	if (win.JustPressed(pixelgl.KeyEnter) && menu.selectedGalleryOptionNumber == menu.NumGalleryOptionIndexes()) || win.JustPressed(pixelgl.KeyEscape) {
+		menu.page = mainPage
+		menu.PrintMenuText(menu.selectedOptionNumber, context.Name())
+		return
+	}
+
+	menu.PrintGalleryMenuText()
+}
+
+func (menu *pauseMenu) PrintGalleryMenuText() {
+	menu.text.Clear()
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "Photo gallery")
+	fmt.Fprintln(menu.text, "------------------------------")
+
+	menu.text.Color = colornames.Pink
+	if directory, err := photosDirectory(); err == nil {
+		fmt.Fprintln(menu.text, "Saved in "+directory)
+	}
+	fmt.Fprintln(menu.text) // New line.
+
+	if len(menu.galleryPhotos) == 0 {
+		menu.text.Color = colornames.Lightgray
+		fmt.Fprintln(menu.text, "No photos yet. Press P to take some.")
+	}
+
+	// Show the page of photos the selection is on (the last page, for back).
+	page := int(math.Max(0, math.Min(float64(menu.selectedGalleryOptionNumber), float64(len(menu.galleryPhotos)-1)))) / galleryPageLength
+	first := page * galleryPageLength
+	for index := first; index < len(menu.galleryPhotos) && index < first+galleryPageLength; index++ {
+		metadata := menu.galleryPhotos[index].metadata
+
+		species := "No birds"
+		if len(metadata.species) > 0 {
+			species = strings.Join(metadata.species, ", ")
+		}
+
+		menu.printGalleryOption(index, fmt.Sprintf("%s: %s (%s)", metadata.taken.Format("Jan 2, 2006 15:04"), species, metadata.feeder))
+	}
+
+	if len(menu.galleryPhotos) > galleryPageLength {
+		menu.text.Color = colornames.Lightgray
+		fmt.Fprintf(menu.text, "%d of %d\n", page+1, (len(menu.galleryPhotos)-1)/galleryPageLength+1)
+	}
+
+	fmt.Fprintln(menu.text) // New line.
+	menu.printGalleryOption(menu.NumGalleryOptionIndexes(), "Back")
+
+	menu.text.Color = colornames.White
+	fmt.Fprintln(menu.text, "------------------------------")
+}
+
+func (menu *pauseMenu) printGalleryOption(optionNumber int, label string) {
+	if menu.selectedGalleryOptionNumber == optionNumber {
+		menu.text.Color = colornames.Red
+	} else {
+		menu.text.Color = colornames.Blue
+	}
+
+	fmt.Fprintln(menu.text, label)
+}
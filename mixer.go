@@ -0,0 +1,185 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/speaker"
+)
+
+const speakerSampleRate = beep.SampleRate(44100)
+
+type audioBus int
+
+const (
+	ambienceBus audioBus = iota
+	songsBus
+)
+
+// Buses in the order they are listed in the audio settings.
+var audioBuses = []audioBus{
+	ambienceBus,
+	songsBus,
+}
+
+var audioBusNames = map[audioBus]string{
+	ambienceBus: "Ambience",
+	songsBus:    "Songs",
+}
+
+// Scales a streamer by a gain that glides smoothly towards its target instead of jumping.
+type fader struct {
+	Streamer beep.Streamer
+
+	gain   float64
+	target float64
+	step   float64
+
+	// Finish once faded out completely, so the mixer lets go of it.
+	drainWhenSilent bool
+}
+
+func (fader *fader) Stream(samples [][2]float64) (n int, ok bool) {
+	if fader.drainWhenSilent && fader.gain == 0 && fader.target == 0 {
+		return 0, false
+	}
+
+	n, ok = fader.Streamer.Stream(samples)
+
+	for i := range samples[:n] {
+		if fader.gain < fader.target {
+			fader.gain = math.Min(fader.gain+fader.step, fader.target)
+		} else if fader.gain > fader.target {
+			fader.gain = math.Max(fader.gain-fader.step, fader.target)
+		}
+
+		samples[i][0] *= fader.gain
+		samples[i][1] *= fader.gain
+	}
+
+	return n, ok
+}
+
+func (fader *fader) Err() error {
+	return fader.Streamer.Err()
+}
+
+// Glide to the target gain over the given length. The speaker must be locked when this is called.
+func (fader *fader) fadeTo(target float64, length time.Duration) {
+	fader.target = target
+	fader.step = math.Abs(target-fader.gain) / math.Max(1, float64(speakerSampleRate.N(length)))
+
+	if length <= 0 {
+		fader.gain = target
+	}
+}
+
+type mixerBus struct {
+	mixer  *beep.Mixer
+	fader  *fader
+	volume float64
+	muted  bool
+}
+
+func (bus *mixerBus) gain() float64 {
+	if bus.muted {
+		return 0
+	}
+
+	return bus.volume
+}
+
+// Every sound is played through one of the mixer's buses, which all feed into the master volume.
+type audioMixer struct {
+	master       *fader
+	masterVolume float64
+	buses        map[audioBus]*mixerBus
+	started      bool
+}
+
+var mixer = newAudioMixer()
+
+func newAudioMixer() *audioMixer {
+	masterMixer := &beep.Mixer{}
+	audioMixer := &audioMixer{
+		master:       &fader{Streamer: masterMixer, gain: defaultMasterVolume, target: defaultMasterVolume},
+		masterVolume: defaultMasterVolume,
+		buses:        map[audioBus]*mixerBus{},
+	}
+
+	for _, bus := range audioBuses {
+		busMixer := &beep.Mixer{}
+		busFader := &fader{Streamer: busMixer, gain: defaultBusVolume, target: defaultBusVolume}
+		audioMixer.buses[bus] = &mixerBus{mixer: busMixer, fader: busFader, volume: defaultBusVolume}
+		masterMixer.Add(busFader)
+	}
+
+	return audioMixer
+}
+
+// Initialize the speaker and start streaming the mixer through it. Only happens once.
+func (mixer *audioMixer) start() {
+	if mixer.started {
+		return
+	}
+
+	speaker.Init(speakerSampleRate, speakerSampleRate.N(time.Second/60))
+	speaker.Play(mixer.master)
+	mixer.started = true
+}
+
+func (mixer *audioMixer) play(bus audioBus, streamer beep.Streamer) {
+	speaker.Lock()
+	mixer.buses[bus].mixer.Add(streamer)
+	speaker.Unlock()
+}
+
+// Stop everything currently playing on the given buses.
+func (mixer *audioMixer) clear(buses ...audioBus) {
+	speaker.Lock()
+	for _, bus := range buses {
+		mixer.buses[bus].mixer.Clear()
+	}
+	speaker.Unlock()
+}
+
+func (mixer *audioMixer) setMasterVolume(volume float64) {
+	mixer.masterVolume = math.Max(0, math.Min(1, volume))
+
+	speaker.Lock()
+	mixer.master.fadeTo(mixer.masterGain(), mixerFadeLength)
+	speaker.Unlock()
+}
+
+func (mixer *audioMixer) masterGain() float64 {
+	if soundDisabled {
+		return 0
+	}
+
+	return mixer.masterVolume
+}
+
+// Fade the master volume back in, e.g. after unmuting.
+func (mixer *audioMixer) fadeIn() {
+	speaker.Lock()
+	mixer.master.gain = 0
+	mixer.master.fadeTo(mixer.masterGain(), mixerFadeLength)
+	speaker.Unlock()
+}
+
+func (mixer *audioMixer) setVolume(bus audioBus, volume float64) {
+	mixer.buses[bus].volume = math.Max(0, math.Min(1, volume))
+	mixer.refresh(bus)
+}
+
+func (mixer *audioMixer) setMuted(bus audioBus, muted bool) {
+	mixer.buses[bus].muted = muted
+	mixer.refresh(bus)
+}
+
+func (mixer *audioMixer) refresh(bus audioBus) {
+	speaker.Lock()
+	mixer.buses[bus].fader.fadeTo(mixer.buses[bus].gain(), mixerFadeLength)
+	speaker.Unlock()
+}
@@ -4,4 +4,10 @@ type perch struct {
 	X        *coordinatePair
 	Y        *coordinatePair
 	occupied bool
+
+	// Waiting perches are off the feeder (branches, fences, rooflines). Birds can sit on them, but not eat.
+	waiting bool
+
+	// Name of the scenery a waiting perch is on.
+	area string
 }
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// What's written into each photo alongside the picture, as PNG tEXt chunks. The keywords are the standard ones
+// where there is one, so other programs show them too.
+type photoMetadata struct {
+	taken   time.Time
+	species []string
+	feeder  string
+	region  string
+}
+
+const (
+	pngSignatureLength = 8
+	pngHeaderEnd       = pngSignatureLength + 4 + 4 + 13 + 4
+
+	// The PNG spec's own format for Creation Time.
+	photoTimeFormat = time.RFC1123Z
+)
+
+// Formats Creation Time is read in. Photos taken before it followed the spec were written in RFC 3339.
+var photoTimeFormats = []string{photoTimeFormat, time.RFC3339}
+
+func photosDirectory() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, settingsDirectoryName, photosDirectoryName), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Save a photo, returning where it went.
+func savePhoto(img image.Image, metadata photoMetadata) (string, error) {
+	directory, err := photosDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+
+	encoded := &bytes.Buffer{}
+	if err := png.Encode(encoded, img); err != nil {
+		return "", err
+	}
+
+	contents, err := insertPNGText(encoded.Bytes(), metadata.textChunks())
+	if err != nil {
+		return "", err
+	}
+
+	// Photos taken within the same second are numbered rather than written over each other.
+	name := "feedr-" + metadata.taken.Format("2006-01-02-150405")
+	path := filepath.Join(directory, name+".png")
+	for number := 2; fileExists(path); number++ {
+		path = filepath.Join(directory, fmt.Sprintf("%s-%d.png", name, number))
+	}
+
+	return path, ioutil.WriteFile(path, contents, 0644)
+}
+
+func (metadata photoMetadata) textChunks() [][2]string {
+	species := "No birds"
+	if len(metadata.species) > 0 {
+		species = strings.Join(metadata.species, ", ")
+	}
+
+	return [][2]string{
+		{"Title", "Feedr photo"},
+		{"Software", "Feedr"},
+		{"Creation Time", metadata.taken.Format(photoTimeFormat)},
+		{"Description", species + " at the " + metadata.feeder + " (" + metadata.region + ")"},
+		{"Species", strings.Join(metadata.species, ", ")},
+		{"Feeder", metadata.feeder},
+		{"Region", metadata.region},
+	}
+}
+
+// The standard library doesn't write text chunks, so they're slotted in straight after the header, where any
+// reader will find them.
+func insertPNGText(encoded []byte, entries [][2]string) ([]byte, error) {
+	if len(encoded) < pngHeaderEnd || string(encoded[pngSignatureLength+4:pngSignatureLength+8]) != "IHDR" {
+		return nil, errors.New("not a PNG with a header")
+	}
+
+	contents := &bytes.Buffer{}
+	contents.Write(encoded[:pngHeaderEnd])
+
+	for _, entry := range entries {
+		data := append(append([]byte(entry[0]), 0), []byte(entry[1])...)
+
+		binary.Write(contents, binary.BigEndian, uint32(len(data)))
+		chunk := append([]byte("tEXt"), data...)
+		contents.Write(chunk)
+		binary.Write(contents, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	}
+
+	contents.Write(encoded[pngHeaderEnd:])
+	return contents.Bytes(), nil
+}
+
+// Read the text chunks back out of a PNG, by keyword.
+func readPNGText(contents []byte) map[string]string {
+	entries := map[string]string{}
+
+	for offset := pngSignatureLength; offset+8 <= len(contents); {
+		length := int(binary.BigEndian.Uint32(contents[offset : offset+4]))
+		chunkType := string(contents[offset+4 : offset+8])
+		if offset+12+length > len(contents) || chunkType == "IDAT" {
+			break
+		}
+
+		if chunkType == "tEXt" {
+			data := contents[offset+8 : offset+8+length]
+			if separator := bytes.IndexByte(data, 0); separator > 0 {
+				entries[string(data[:separator])] = string(data[separator+1:])
+			}
+		}
+
+		offset += 12 + length
+	}
+
+	return entries
+}
+
+func parsePhotoTime(value string) (time.Time, bool) {
+	for _, format := range photoTimeFormats {
+		if taken, err := time.Parse(format, value); err == nil {
+			return taken, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// The start of a photo, which is as far as the text chunks go. Saves reading the whole picture just to list it.
+func readPhotoHeader(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	contents := make([]byte, photoHeaderReadLength)
+	read, err := io.ReadFull(file, contents)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	return contents[:read], nil
+}
+
+// A photo in the gallery.
+type galleryPhoto struct {
+	path     string
+	metadata photoMetadata
+}
+
+// Every photo taken so far, newest first.
+func listPhotos() []galleryPhoto {
+	directory, err := photosDirectory()
+	if err != nil {
+		return nil
+	}
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil
+	}
+
+	photos := []galleryPhoto{}
+	for _, file := range files {
+		if file.IsDir() || strings.ToLower(filepath.Ext(file.Name())) != ".png" {
+			continue
+		}
+
+		path := filepath.Join(directory, file.Name())
+		contents, err := readPhotoHeader(path)
+		if err != nil {
+			continue
+		}
+
+		entries := readPNGText(contents)
+		metadata := photoMetadata{feeder: entries["Feeder"], region: entries["Region"], taken: file.ModTime()}
+		if taken, found := parsePhotoTime(entries["Creation Time"]); found {
+			metadata.taken = taken
+		}
+		if entries["Species"] != "" {
+			metadata.species = strings.Split(entries["Species"], ", ")
+		}
+
+		photos = append(photos, galleryPhoto{path: path, metadata: metadata})
+	}
+
+	sort.Slice(photos, func(i, j int) bool {
+		return photos[i].metadata.taken.After(photos[j].metadata.taken)
+	})
+
+	return photos
+}
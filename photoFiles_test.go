@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPNGTextRoundTrip(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	img.Set(1, 2, color.RGBA{R: 200, G: 40, B: 10, A: 255})
+
+	encoded := &bytes.Buffer{}
+	if err := png.Encode(encoded, img); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := photoMetadata{
+		taken:   time.Date(2024, time.January, 5, 7, 30, 0, 0, time.UTC),
+		species: []string{"Black-capped Chickadee", "Northern Cardinal"},
+		feeder:  "Standard House Feeder",
+		region:  "Eastern North America",
+	}
+
+	contents, err := insertPNGText(encoded.Bytes(), metadata.textChunks())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The picture must still be a valid PNG, unchanged by the chunks slotted into it.
+	decoded, err := png.Decode(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatalf("photo with text doesn't decode: %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() || color.RGBAModel.Convert(decoded.At(1, 2)) != img.At(1, 2) {
+		t.Error("picture changed by the text chunks")
+	}
+
+	want := map[string]string{}
+	for _, entry := range metadata.textChunks() {
+		want[entry[0]] = entry[1]
+	}
+
+	if got := readPNGText(contents); !reflect.DeepEqual(got, want) {
+		t.Errorf("read back %v, want %v", got, want)
+	}
+
+	// Everything read back lives in the header, ahead of the picture data.
+	header := contents[:len(contents)-(encoded.Len()-pngHeaderEnd)]
+	if got := readPNGText(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("read back %v from the header, want %v", got, want)
+	}
+}
+
+func TestInsertPNGTextRejectsOtherFiles(t *testing.T) {
+	if _, err := insertPNGText([]byte("GIF89a, not a PNG at all, but long enough"), nil); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestParsePhotoTime(t *testing.T) {
+	taken := time.Date(2024, time.May, 12, 6, 45, 10, 0, time.FixedZone("EDT", -4*60*60))
+
+	tests := []struct {
+		value string
+		found bool
+	}{
+		{taken.Format(photoTimeFormat), true},
+		{taken.Format(time.RFC3339), true},
+		{"last Tuesday", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		got, found := parsePhotoTime(test.value)
+		if found != test.found {
+			t.Errorf("parsePhotoTime(%q) found %v, want %v", test.value, found, test.found)
+		} else if found && !got.Equal(taken) {
+			t.Errorf("parsePhotoTime(%q) = %v, want %v", test.value, got, taken)
+		}
+	}
+}
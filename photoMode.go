@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// The scene stopped still, with a camera that can be moved about and zoomed in to take photos.
+type photoMode struct {
+	active bool
+
+	// Where the camera is pointing, and how far it's zoomed in.
+	position pixel.Vec
+	zoom     float64
+
+	// When the scene stopped still, so everything due can be put back by as long as it stood still for.
+	pausedAt time.Time
+
+	// When the last photo was taken (for the flash), and what became of it.
+	lastShot time.Time
+	message  string
+
+	imd  *imdraw.IMDraw
+	text *text.Text
+}
+
+var photo = &photoMode{zoom: 1}
+
+// Pick the camera up from wherever the scene's was, or put it down again and let the birds carry on where they were.
+func (mode *photoMode) toggle(cameraPosition pixel.Vec, birds []*bird) {
+	mode.active = !mode.active
+	if mode.active {
+		mode.pausedAt = time.Now()
+	} else {
+		postponeSimulation(birds, time.Since(mode.pausedAt))
+	}
+
+	mode.position = cameraPosition
+	mode.zoom = 1
+	mode.message = ""
+}
+
+// Arrow keys pan, and plus and minus (or the scroll wheel) zoom.
+func (mode *photoMode) update(win *pixelgl.Window, elapsed float64) {
+	zoomChange := win.MouseScroll().Y
+	if win.JustPressed(pixelgl.KeyEqual) {
+		zoomChange++
+	} else if win.JustPressed(pixelgl.KeyMinus) {
+		zoomChange--
+	}
+	mode.zoom = math.Max(1, math.Min(photoMaxZoom, mode.zoom*math.Pow(photoZoomStep, zoomChange)))
+
+	// Panning slows as the camera zooms in, so it moves at the same speed across the screen.
+	pan := pixel.ZV
+	if win.Pressed(pixelgl.KeyLeft) {
+		pan.X--
+	}
+	if win.Pressed(pixelgl.KeyRight) {
+		pan.X++
+	}
+	if win.Pressed(pixelgl.KeyDown) {
+		pan.Y--
+	}
+	if win.Pressed(pixelgl.KeyUp) {
+		pan.Y++
+	}
+	mode.position = mode.position.Add(pan.Scaled(photoPanSpeed * elapsed / mode.zoom))
+
+	// Keep the view inside the scene. The camera shows the scene from position+visible/zoom.
+	min := layout.canvasBounds.Min.Sub(layout.visibleBounds.Min.Scaled(1 / mode.zoom))
+	max := layout.canvasBounds.Max.Sub(layout.visibleBounds.Max.Scaled(1 / mode.zoom))
+	mode.position = pixel.V(
+		math.Max(min.X, math.Min(max.X, mode.position.X)),
+		math.Max(min.Y, math.Min(max.Y, mode.position.Y)),
+	)
+}
+
+func (mode *photoMode) camera() pixel.Matrix {
+	return pixel.IM.Moved(mode.position.Scaled(-1)).Scaled(pixel.ZV, mode.zoom)
+}
+
+// Save what's on the canvas, noting down who's in the shot.
+func (mode *photoMode) take(canvas *pixelgl.Canvas, birds []*bird) {
+	metadata := photoMetadata{
+		taken:   time.Now(),
+		species: mode.speciesInShot(birds),
+		feeder:  context.Name(),
+		region:  regionNames[currentRegion],
+	}
+
+	path, err := savePhoto(canvasImage(canvas), metadata)
+	if err != nil {
+		mode.message = "Couldn't save the photo: " + err.Error()
+	} else {
+		mode.message = "Saved " + filepath.Base(path)
+	}
+
+	mode.lastShot = time.Now()
+}
+
+// The species of every bird at least partly in view, by name.
+func (mode *photoMode) speciesInShot(birds []*bird) []string {
+	camera := mode.camera()
+	view := pixel.Rect{Min: camera.Unproject(layout.visibleBounds.Min), Max: camera.Unproject(layout.visibleBounds.Max)}.Norm()
+
+	inShot := map[string]bool{}
+	for _, bird := range birds {
+		if !bird.removed && bird.physics.rect.Intersects(view) {
+			inShot[bird.species.Name()] = true
+		}
+	}
+
+	return sortedNames(inShot)
+}
+
+// The canvas as an image, the right way up. The canvas stores its rows bottom first.
+func canvasImage(canvas *pixelgl.Canvas) image.Image {
+	width, height := int(canvas.Bounds().W()), int(canvas.Bounds().H())
+	pixels := canvas.Pixels()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height && (y+1)*width*4 <= len(pixels); y++ {
+		copy(img.Pix[(height-1-y)*img.Stride:], pixels[y*width*4:(y+1)*width*4])
+	}
+
+	return img
+}
+
+// Draw the flash, the controls and what became of the last photo. Drawn after the photo's taken, so it's never in it.
+func (mode *photoMode) draw(canvas *pixelgl.Canvas) {
+	if mode.text == nil {
+		mode.imd = imdraw.New(nil)
+		mode.text = text.New(pixel.ZV, text.NewAtlas(basicfont.Face7x13, text.ASCII))
+	}
+	imd := mode.imd
+	imd.Clear()
+	mode.text.Clear()
+
+	// The overlay stays put on screen, whatever the camera's doing.
+	canvas.SetMatrix(pixel.IM)
+
+	visible := layout.visibleBounds
+	if sinceShot := time.Since(mode.lastShot); sinceShot < photoFlashLength {
+		imd.Color = pixel.Alpha(1 - float64(sinceShot)/float64(photoFlashLength))
+		imd.Push(visible.Min, visible.Max)
+		imd.Rectangle(0)
+	}
+
+	lines := []string{fmt.Sprintf("Photo mode (%.1fx): arrows to pan, +/- to zoom, space to take a photo, P to leave", mode.zoom)}
+	if mode.message != "" {
+		lines = append(lines, mode.message)
+	}
+
+	lineHeight := mode.text.Atlas().LineHeight() * captionTextScale
+	height := lineHeight*float64(len(lines)) + captionPadding*2
+	imd.Color = color.RGBA{A: 170}
+	imd.Push(visible.Min, pixel.V(visible.Max.X, visible.Min.Y+height))
+	imd.Rectangle(0)
+	imd.Draw(canvas)
+
+	mode.text.Color = color.White
+	for index, line := range lines {
+		mode.text.Dot = pixel.V(visible.Min.X+captionPadding, visible.Min.Y+height-captionPadding-lineHeight*float64(index+1)+lineHeight/4).Scaled(1 / captionTextScale)
+		mode.text.WriteString(line)
+	}
+	mode.text.Draw(canvas, pixel.IM.Scaled(pixel.ZV, captionTextScale))
+}
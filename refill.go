@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type refillPolicy int
+
+const (
+	// Only refilled by pressing enter.
+	manualRefill refillPolicy = iota
+
+	// Filled right up every morning.
+	scheduledRefill
+
+	// Topped up whenever it gets low.
+	thresholdRefill
+
+	// Filled by hand from a bag of seed, which runs out and has to be restocked.
+	seedBagRefill
+)
+
+// Policies in the order they are cycled through in the pause menu.
+var refillPolicies = []refillPolicy{
+	manualRefill,
+	scheduledRefill,
+	thresholdRefill,
+	seedBagRefill,
+}
+
+var refillPolicyNames = map[refillPolicy]string{
+	manualRefill:    "Manual",
+	scheduledRefill: "Every morning",
+	thresholdRefill: "Top up when low",
+	seedBagRefill:   "Seed bag",
+}
+
+type seedRefiller struct {
+	policy refillPolicy
+
+	// When the feeder was last filled on schedule, so it's only done once a morning.
+	lastScheduledRefill time.Time
+
+	// How much is left in the seed bag, from 0 (empty) to 1 (a fresh bag).
+	bag float64
+}
+
+var refiller = &seedRefiller{policy: manualRefill, bag: 1}
+
+func cycleRefillPolicy() {
+	for index, policy := range refillPolicies {
+		if policy == refiller.policy {
+			refiller.policy = refillPolicies[(index+1)%len(refillPolicies)]
+			return
+		}
+	}
+}
+
+// The policy actually in use. A display left running on its own can't wait for anyone to press enter, a morning that
+// never comes or a bag nobody restocks, so whatever was chosen it tops the feeder up instead.
+func (refiller *seedRefiller) activePolicy() refillPolicy {
+	if displayModeEnabled() {
+		return thresholdRefill
+	}
+
+	return refiller.policy
+}
+
+// Refill the feeder when the policy says it's time.
+func (refiller *seedRefiller) update() {
+	seed := context.Seed()
+
+	switch refiller.activePolicy() {
+	case scheduledRefill:
+		now := time.Now()
+		if now.Hour() >= scheduledRefillHour && !sameDay(refiller.lastScheduledRefill, now) {
+			pourSeed(seed.originalSeedCount)
+			refiller.lastScheduledRefill = now
+			saveSettings()
+		}
+	case thresholdRefill:
+		if seed.seedCount <= seed.originalSeedCount*refillThreshold {
+			pourSeed(seed.originalSeedCount)
+		}
+	}
+}
+
+// A handful of seed, as when enter is pressed. With the seed bag, it comes out of the bag, and there's none once the
+// bag is empty.
+func (refiller *seedRefiller) refillByHand() {
+	seed := context.Seed()
+	amount := seed.originalSeedCount * defaultSeedRefillMultiplier
+
+	if refiller.activePolicy() != seedBagRefill {
+		pourSeed(amount)
+		return
+	}
+
+	// The bag holds a set number of feeder-fulls, whatever size the feeder is. It's paid for up front, when restocked.
+	bagSize := seed.originalSeedCount * seedBagFeederFills
+	added := seed.add(math.Min(amount, refiller.bag*bagSize))
+	refiller.bag = math.Max(0, refiller.bag-added/bagSize)
+}
+
+// Swap the empty bag for a fresh one, if it can be paid for. Returns whether it was.
+func (refiller *seedRefiller) restock() bool {
+	if !economy.spend(seedBagCost) {
+		return false
+	}
+
+	refiller.bag = 1
+	return true
+}
+
+// What the pause menu shows for the policy, e.g. "Refill: Seed bag (40% left)".
+func (refiller *seedRefiller) description() string {
+	description := "Refill: " + refillPolicyNames[refiller.policy]
+
+	switch {
+	case refiller.activePolicy() != refiller.policy:
+		description += " (topping up while on display)"
+	case refiller.policy == seedBagRefill:
+		description += fmt.Sprintf(" (%d%% left)", int(math.Round(refiller.bag*100)))
+	}
+
+	return description
+}
+
+func sameDay(first, second time.Time) bool {
+	firstYear, firstMonth, firstDay := first.Date()
+	secondYear, secondMonth, secondDay := second.Date()
+	return firstYear == secondYear && firstMonth == secondMonth && firstDay == secondDay
+}
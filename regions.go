@@ -0,0 +1,151 @@
+package main
+
+type region int
+
+const (
+	easternNorthAmerica region = iota
+	pacificNorthwest
+	unitedKingdom
+	centralEurope
+)
+
+// Regions in the order they are cycled through in the menus.
+var regions = []region{
+	easternNorthAmerica,
+	pacificNorthwest,
+	unitedKingdom,
+	centralEurope,
+}
+
+var regionNames = map[region]string{
+	easternNorthAmerica: "Eastern North America",
+	pacificNorthwest:    "Pacific Northwest",
+	unitedKingdom:       "United Kingdom",
+	centralEurope:       "Central Europe",
+}
+
+// The region the feeder is in. Determines which birds are around at which times of the year.
+var currentRegion = easternNorthAmerica
+
+// The birds found in a region, at the feeder and further off.
+type regionCatalog struct {
+	// Every species that can show up in this region.
+	species []species
+
+	// Likelihoods that override a feeder context's own, by species name.
+	likelihoods map[string]uint
+
+	// Birds only heard in the distance.
+	voices []soundscapeVoice
+}
+
+var regionCatalogs = map[region]regionCatalog{
+	easternNorthAmerica: {
+		species: []species{
+			&cardinal{},
+			&downyWoodpecker{},
+			&chickadee{},
+			&titmouse{},
+			&junco{},
+			&warbler{},
+		},
+		likelihoods: map[string]uint{},
+		voices: []soundscapeVoice{
+			{name: "American Crow", mnemonic: "caw caw caw", path: "sounds/soundscape/americanCrow.wav", presence: resident, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "Blue Jay", mnemonic: "jay! jay!", path: "sounds/soundscape/blueJay.wav", presence: resident, likelihood: 250, dayPhasePercents: diurnalCallPercents},
+			{name: "Mourning Dove", mnemonic: "coo-OO-oo-oo", path: "sounds/soundscape/mourningDove.wav", presence: resident, likelihood: 200, dayPhasePercents: diurnalCallPercents},
+			{name: "American Robin", mnemonic: "cheerily cheer-up cheerio", path: "sounds/soundscape/americanRobin.wav", presence: summerBreeder, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "Great Horned Owl", mnemonic: "hoo h'HOO hoo hoo", path: "sounds/soundscape/greatHornedOwl.wav", presence: resident, likelihood: 100, dayPhasePercents: nocturnalCallPercents},
+		},
+	},
+	pacificNorthwest: {
+		species: []species{
+			&downyWoodpecker{},
+			&chickadee{},
+			&junco{},
+			&warbler{},
+		},
+		likelihoods: map[string]uint{
+			"Black-capped Chickadee": 400,
+			"Downy Woodpecker":       250,
+		},
+		voices: []soundscapeVoice{
+			{name: "Steller's Jay", mnemonic: "shook shook shook", path: "sounds/soundscape/stellersJay.wav", presence: resident, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "Varied Thrush", mnemonic: "eeeeee", path: "sounds/soundscape/variedThrush.wav", presence: winterVisitor, likelihood: 200, dayPhasePercents: diurnalCallPercents},
+			{name: "American Crow", mnemonic: "caw caw caw", path: "sounds/soundscape/americanCrow.wav", presence: resident, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "Barred Owl", mnemonic: "who cooks for you", path: "sounds/soundscape/barredOwl.wav", presence: resident, likelihood: 100, dayPhasePercents: nocturnalCallPercents},
+		},
+	},
+	unitedKingdom: {
+		species: []species{
+			&blueTit{},
+			&greatTit{},
+		},
+		likelihoods: map[string]uint{
+			"Blue Tit":  350,
+			"Great Tit": 300,
+		},
+		voices: []soundscapeVoice{
+			{name: "Common Blackbird", mnemonic: "mellow fluting, then a scratchy flourish", path: "sounds/soundscape/commonBlackbird.wav", presence: resident, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "European Robin", mnemonic: "thin, wistful warbling", path: "sounds/soundscape/europeanRobin.wav", presence: resident, likelihood: 250, dayPhasePercents: diurnalCallPercents},
+			{name: "Common Wood Pigeon", mnemonic: "coo-COO-coo, coo-coo", path: "sounds/soundscape/woodPigeon.wav", presence: resident, likelihood: 250, dayPhasePercents: diurnalCallPercents},
+			{name: "Carrion Crow", mnemonic: "kraa kraa kraa", path: "sounds/soundscape/carrionCrow.wav", presence: resident, likelihood: 200, dayPhasePercents: diurnalCallPercents},
+			{name: "Tawny Owl", mnemonic: "hooo... hu-hu-hooooo", path: "sounds/soundscape/tawnyOwl.wav", presence: resident, likelihood: 100, dayPhasePercents: nocturnalCallPercents},
+		},
+	},
+	centralEurope: {
+		species: []species{
+			&greatTit{},
+			&blueTit{},
+		},
+		likelihoods: map[string]uint{
+			"Great Tit": 350,
+			"Blue Tit":  250,
+		},
+		voices: []soundscapeVoice{
+			{name: "Common Blackbird", mnemonic: "mellow fluting, then a scratchy flourish", path: "sounds/soundscape/commonBlackbird.wav", presence: resident, likelihood: 300, dayPhasePercents: diurnalCallPercents},
+			{name: "Eurasian Jay", mnemonic: "kschaaak!", path: "sounds/soundscape/eurasianJay.wav", presence: resident, likelihood: 200, dayPhasePercents: diurnalCallPercents},
+			{name: "Common Wood Pigeon", mnemonic: "coo-COO-coo, coo-coo", path: "sounds/soundscape/woodPigeon.wav", presence: resident, likelihood: 250, dayPhasePercents: diurnalCallPercents},
+			{name: "Carrion Crow", mnemonic: "kraa kraa kraa", path: "sounds/soundscape/carrionCrow.wav", presence: resident, likelihood: 250, dayPhasePercents: diurnalCallPercents},
+			{name: "Tawny Owl", mnemonic: "hooo... hu-hu-hooooo", path: "sounds/soundscape/tawnyOwl.wav", presence: resident, likelihood: 100, dayPhasePercents: nocturnalCallPercents},
+		},
+	},
+}
+
+func cycleRegion() {
+	for index, region := range regions {
+		if region == currentRegion {
+			currentRegion = regions[(index+1)%len(regions)]
+			return
+		}
+	}
+}
+
+// The likelihood of each species showing up at the current feeder in the current region. Species come from the region's
+// catalog, weighted by the feeder context's likelihoods (or a default for species it doesn't list) and the region's overrides.
+func regionalBirdLikelihoods() map[species]uint {
+	contextLikelihoods := map[string]uint{}
+	for species, likelihood := range context.BirdLikelihoods() {
+		species.Initialize()
+		contextLikelihoods[species.Name()] = likelihood
+	}
+
+	catalog := regionCatalogs[currentRegion]
+	likelihoods := map[species]uint{}
+	for _, species := range catalog.species {
+		species.Initialize()
+
+		likelihood, found := contextLikelihoods[species.Name()]
+		if !found {
+			likelihood = defaultRegionalLikelihood
+		}
+
+		if override, found := catalog.likelihoods[species.Name()]; found {
+			likelihood = override
+		}
+
+		likelihoods[species] = likelihood
+	}
+
+	return likelihoods
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/pixel"
+)
+
+// Anything that can sing and be answered, whether it's on the feeder or out of sight.
+type singer interface {
+	singerSpecies() species
+	singerPosition() pixel.Vec
+
+	// Whether it's free to answer a song right now.
+	canAnswer() bool
+
+	// Sing at the given time, answering the given singer. The exchange counts the songs so far in a bout.
+	answerAt(answerTime time.Time, exchange int, answering singer)
+}
+
+// A song someone is singing, and who (if anyone) they're answering.
+type songRecord struct {
+	singer    singer
+	answering singer
+	exchange  int
+	endTime   time.Time
+}
+
+// Keeps track of who is singing, so that others of the same species can answer them.
+type songRegistry struct {
+	// Everyone singing right now.
+	current []songRecord
+
+	// Songs started since the last update, yet to be answered.
+	heard []songRecord
+}
+
+var songs = &songRegistry{}
+
+// Note down a song as it starts. Exchange is 0 for a song nobody asked for.
+func (registry *songRegistry) started(singer singer, exchange int, answering singer, buffer *beep.Buffer) {
+	record := songRecord{singer: singer, answering: answering, exchange: exchange, endTime: time.Now().Add(soundLength(buffer))}
+	registry.current = append(registry.current, record)
+	registry.heard = append(registry.heard, record)
+
+	simulationEvents.publish(simulationEvent{kind: songHeard, species: singer.singerSpecies(), singer: singer})
+}
+
+func (registry *songRegistry) isSinging(singer singer) bool {
+	for _, record := range registry.current {
+		if record.singer == singer && time.Now().Before(record.endTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (registry *songRegistry) postpone(length time.Duration) {
+	for index := range registry.current {
+		registry.current[index].endTime = postponed(registry.current[index].endTime, length)
+	}
+}
+
+// Forget finished songs, and have someone answer the new ones.
+func (registry *songRegistry) update(birds []*bird) {
+	current := []songRecord{}
+	for _, record := range registry.current {
+		if time.Now().Before(record.endTime) {
+			current = append(current, record)
+		}
+	}
+	registry.current = current
+
+	for _, record := range registry.heard {
+		registry.answer(record, birds)
+	}
+	registry.heard = nil
+}
+
+func (registry *songRegistry) answer(record songRecord, birds []*bird) {
+	species := record.singer.singerSpecies()
+
+	// Bouts only run so long before everyone loses interest.
+	if record.exchange >= species.CountersingingLength() || soundDisabled {
+		return
+	}
+
+	if uint(nextRandomInt(0, likelihoodMaxPercent)) >= species.ResponseLikelihood() {
+		return
+	}
+
+	// Whoever this song answered gets first go at answering back, so that a bout goes back and forth between the same two.
+	candidates := []singer{}
+	for _, listener := range listeners(birds) {
+		if registry.canAnswer(record, listener) {
+			if listener == record.answering {
+				candidates = []singer{listener}
+				break
+			}
+
+			candidates = append(candidates, listener)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	answerer := candidates[nextRandomInt(0, len(candidates))]
+	answerTime := record.endTime.Add(time.Millisecond * time.Duration(nextRandomInt(responseDelayRange.min, responseDelayRange.max)))
+	answerer.answerAt(answerTime, record.exchange+1, record.singer)
+}
+
+// Only birds of the same species close enough to hear, and not already busy singing, answer a song.
+func (registry *songRegistry) canAnswer(record songRecord, listener singer) bool {
+	if listener == record.singer || listener.singerSpecies().Name() != record.singer.singerSpecies().Name() {
+		return false
+	}
+
+	if listener.singerPosition().Sub(record.singer.singerPosition()).Len() > responseMaxDistance {
+		return false
+	}
+
+	return listener.canAnswer() && !registry.isSinging(listener)
+}
+
+// Everyone who might hear a song: the birds in the scene, and those singing out of sight.
+func listeners(birds []*bird) []singer {
+	listeners := []singer{}
+	for _, bird := range birds {
+		listeners = append(listeners, bird)
+	}
+
+	for _, offscreenSinger := range chorus.singers {
+		listeners = append(listeners, offscreenSinger)
+	}
+
+	return listeners
+}
+
+func (bird *bird) singerSpecies() species {
+	return bird.species
+}
+
+func (bird *bird) singerPosition() pixel.Vec {
+	return bird.physics.rect.Center()
+}
+
+func (bird *bird) canAnswer() bool {
+	return bird.perched && !bird.eating && !bird.singing && bird.answerExchange == 0
+}
+
+func (bird *bird) answerAt(answerTime time.Time, exchange int, answering singer) {
+	bird.singingStartTime = answerTime
+	bird.chosenToSing = true
+	bird.answerExchange = exchange
+	bird.answering = answering
+}
+
+func (singer *offscreenSinger) singerSpecies() species {
+	return singer.species
+}
+
+func (singer *offscreenSinger) singerPosition() pixel.Vec {
+	return singer.position
+}
+
+func (singer *offscreenSinger) canAnswer() bool {
+	return !singer.singing && singer.answerExchange == 0
+}
+
+func (singer *offscreenSinger) answerAt(answerTime time.Time, exchange int, answering singer) {
+	singer.singingStartTime = answerTime
+	singer.chosenToSing = true
+	singer.answerExchange = exchange
+	singer.answering = answering
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+var screensaverFlag = flag.Bool("screensaver", false, "run as an always-on display: borderless fullscreen, no cursor, no menu, and seed that never runs out")
+
+// What xscreensaver passes its hacks. GLFW can only draw into windows it made itself, so there's no drawing into
+// xscreensaver's window: -root covers the monitor with a window of our own instead, and -window-id (the preview in
+// the settings dialog) has nothing it can draw into at all.
+var rootFlag = flag.Bool("root", false, "run as an X11 screensaver, in a window of its own over the whole screen (implies -screensaver)")
+var windowIDFlag = flag.String("window-id", "", "not supported: GLFW can't draw into another program's window, so this only prints a note and exits (use -root)")
+
+// Whether Feedr is running as an ambient display rather than as something to play with.
+func displayModeEnabled() bool {
+	return *screensaverFlag || *rootFlag
+}
+
+// Whether there's a window to embed in that we can't draw into. xscreensaver also hands the window over in
+// XSCREENSAVER_WINDOW, but only -root tells us we're wanted over the whole screen.
+func embeddingRequested() bool {
+	return *windowIDFlag != "" || (os.Getenv("XSCREENSAVER_WINDOW") != "" && !*rootFlag)
+}
+
+// A borderless window covering the whole monitor. Unlike true fullscreen, it doesn't change the video mode or grab
+// the display, so other windows (and xscreensaver's own) behave as usual around it.
+func applyDisplayMode(cfg *pixelgl.WindowConfig) {
+	monitor := chooseMonitor(*monitorFlag)
+	width, height := monitor.Size()
+	x, y := monitor.Position()
+
+	cfg.Bounds = pixel.R(0, 0, width, height)
+	cfg.Position = pixel.V(x, y)
+	cfg.Monitor = nil
+	cfg.Undecorated = true
+	cfg.Resizable = false
+	cfg.AlwaysOnTop = *rootFlag
+}
+
+func noteUnsupportedEmbedding() {
+	fmt.Println("drawing into another program's window isn't supported, run with -root or -screensaver instead")
+}
+
+// Nothing on screen is moving: no bird is flying, nobody is singing, and there's no weather or seed in the air.
+// Perched birds still animate, but slowly enough that a lower frame rate doesn't show.
+func sceneIsIdle(birds []*bird) bool {
+	for _, bird := range birds {
+		if bird.entering || bird.exiting {
+			return false
+		}
+	}
+
+	return len(songs.current) == 0 && len(weather.particles) == 0 && len(seedParticles.particles) == 0 && len(captions.captions) == 0
+}
+
+// Sleep off the rest of the frame when the scene is idle, so an always-on display isn't drawing flat out for nothing.
+func throttleIdleFrame(frameStart time.Time, birds []*bird) {
+	if !sceneIsIdle(birds) {
+		return
+	}
+
+	if remaining := time.Second/idleFrameRate - time.Since(frameStart); remaining > 0 {
+		time.Sleep(remaining)
+	}
+}
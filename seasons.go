@@ -0,0 +1,55 @@
+package main
+
+import "time"
+
+type season int
+
+const (
+	winter season = iota
+	spring
+	summer
+	autumn
+)
+
+// How a species is present in a region over the course of a year.
+type presence int
+
+const (
+	resident presence = iota
+	winterVisitor
+	passageMigrant
+	summerBreeder
+)
+
+// Percentage of a species' usual likelihood to apply in each month (January first), by presence.
+var presenceMonthlyPercents = map[presence][12]uint{
+	resident:       {100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100},
+	winterVisitor:  {100, 100, 70, 20, 0, 0, 0, 0, 0, 30, 80, 100},
+	passageMigrant: {0, 0, 10, 60, 100, 10, 0, 20, 80, 50, 0, 0},
+	summerBreeder:  {0, 0, 10, 60, 100, 100, 100, 90, 50, 10, 0, 0},
+}
+
+func getSeason(date time.Time) season {
+	switch date.Month() {
+	case time.December, time.January, time.February:
+		return winter
+	case time.March, time.April, time.May:
+		return spring
+	case time.June, time.July, time.August:
+		return summer
+	default:
+		return autumn
+	}
+}
+
+// Scale a species' likelihood by how present it is in the current region on the given date.
+func seasonalLikelihood(species species, likelihood uint, date time.Time) uint {
+	speciesPresence, found := species.Presence()[currentRegion]
+
+	// Species that don't occur in this region never show up.
+	if !found {
+		return 0
+	}
+
+	return likelihood * presenceMonthlyPercents[speciesPresence][date.Month()-1] / 100
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// A bit of seed flicked off the feeder, falling to the ground.
+type seedParticle struct {
+	position pixel.Vec
+	velocity pixel.Vec
+	life     float64
+}
+
+type seedParticleSystem struct {
+	particles []*seedParticle
+}
+
+var seedParticles = &seedParticleSystem{}
+
+// Scatter a few bits of seed from the given point, mostly in the direction the bird is facing.
+func (system *seedParticleSystem) burst(position pixel.Vec, direction float64) {
+	count := nextRandomInt(seedParticleBurstRange.min, seedParticleBurstRange.max)
+	for i := 0; i < count; i++ {
+		system.particles = append(system.particles, &seedParticle{
+			position: position,
+			velocity: pixel.V(-direction*nextRandomFloat64(20, 140), nextRandomFloat64(40, 160)),
+			life:     seedParticleLifetime,
+		})
+	}
+}
+
+func (system *seedParticleSystem) update(elapsed float64) {
+	remaining := system.particles[:0]
+	for _, particle := range system.particles {
+		particle.velocity.Y -= seedParticleGravity * elapsed
+		particle.position = particle.position.Add(particle.velocity.Scaled(elapsed))
+		particle.life -= elapsed
+
+		if particle.life > 0 && particle.position.Y >= layout.canvasBounds.Min.Y {
+			remaining = append(remaining, particle)
+		}
+	}
+
+	system.particles = remaining
+}
+
+func (system *seedParticleSystem) draw(imd *imdraw.IMDraw) {
+	imd.Color = color.RGBA{R: 95, G: 70, B: 40, A: 255}
+	for _, particle := range system.particles {
+		imd.Push(particle.position)
+		imd.Circle(2, 0)
+	}
+}
+
+// Things that happen on particular frames of the bird's animation.
+func (bird *bird) onAnimationEvent(event string) {
+	switch event {
+	case "peck":
+		// Seed only goes flying if there's any left to peck at.
+		if !context.Seed().Finished {
+			seedParticles.burst(bird.beakPosition(), bird.animation.direction)
+		}
+	}
+}
+
+// Roughly where the bird's beak is, given which way it's facing.
+func (bird *bird) beakPosition() pixel.Vec {
+	rect := bird.physics.rect
+	return pixel.V(rect.Center().X-bird.animation.direction*rect.W()*.4, rect.Center().Y-rect.H()*.1)
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Everything remembered between runs.
+type settings struct {
+	SoundDisabled bool               `json:"soundDisabled"`
+	MasterVolume  float64            `json:"masterVolume"`
+	BusVolumes    map[string]float64 `json:"busVolumes"`
+	MutedBuses    map[string]bool    `json:"mutedBuses"`
+	Region        string             `json:"region"`
+
+	// Accessibility.
+	Captions          bool `json:"captions"`
+	SongVisualisation bool `json:"songVisualisation"`
+
+	// Refilling. How much of the seed bag is used (rather than left), so that a new settings file starts on a full bag.
+	RefillPolicy        string    `json:"refillPolicy"`
+	SeedBagUsed         float64   `json:"seedBagUsed"`
+	LastScheduledRefill time.Time `json:"lastScheduledRefill"`
+
+	Economy economySettings `json:"economy"`
+
+	// When each achievement was earned, by id, and how long Feedr has run since the feeder was last empty.
+	Achievements     map[string]time.Time `json:"achievements"`
+	FeederStockedFor time.Duration        `json:"feederStockedFor"`
+}
+
+type economySettings struct {
+	Enabled     bool     `json:"enabled"`
+	Coins       int      `json:"coins"`
+	SeenSpecies []string `json:"seenSpecies"`
+	Unlocked    []string `json:"unlocked"`
+	SeedType    string   `json:"seedType"`
+}
+
+func settingsPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, settingsDirectoryName, settingsFileName), nil
+}
+
+// Apply the saved settings, if there are any. Otherwise everything stays at its default.
+func loadSettings() {
+	path, err := settingsPath()
+	if err != nil {
+		fmt.Println("could not find the settings file: " + err.Error())
+		return
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		fmt.Println("could not read the settings file: " + err.Error())
+		return
+	}
+
+	savedSettings := settings{}
+	if err := json.Unmarshal(contents, &savedSettings); err != nil {
+		fmt.Println("could not parse the settings file: " + err.Error())
+		return
+	}
+
+	savedSettings.apply()
+}
+
+func (savedSettings settings) apply() {
+	soundDisabled = savedSettings.SoundDisabled
+	mixer.setMasterVolume(savedSettings.MasterVolume)
+
+	for _, bus := range audioBuses {
+		if volume, found := savedSettings.BusVolumes[audioBusNames[bus]]; found {
+			mixer.setVolume(bus, volume)
+		}
+
+		mixer.setMuted(bus, savedSettings.MutedBuses[audioBusNames[bus]])
+	}
+
+	for region, name := range regionNames {
+		if name == savedSettings.Region {
+			currentRegion = region
+		}
+	}
+
+	captionsEnabled = savedSettings.Captions
+	songVisualisationEnabled = savedSettings.SongVisualisation
+
+	for policy, name := range refillPolicyNames {
+		if name == savedSettings.RefillPolicy {
+			refiller.policy = policy
+		}
+	}
+	refiller.bag = 1 - savedSettings.SeedBagUsed
+	refiller.lastScheduledRefill = savedSettings.LastScheduledRefill
+
+	economy.enabled = savedSettings.Economy.Enabled
+	economy.coins = savedSettings.Economy.Coins
+	for _, name := range savedSettings.Economy.SeenSpecies {
+		economy.seenSpecies[name] = true
+	}
+	for _, name := range savedSettings.Economy.Unlocked {
+		economy.unlocked[name] = true
+	}
+	if _, found := seedTypes[savedSettings.Economy.SeedType]; found {
+		economy.seedType = savedSettings.Economy.SeedType
+	}
+
+	for id, earned := range savedSettings.Achievements {
+		achievements.earned[id] = earned
+	}
+	achievements.stockedFor = savedSettings.FeederStockedFor
+}
+
+func currentSettings() settings {
+	currentSettings := settings{
+		SoundDisabled: soundDisabled,
+		MasterVolume:  mixer.masterVolume,
+		BusVolumes:    map[string]float64{},
+		MutedBuses:    map[string]bool{},
+		Region:        regionNames[currentRegion],
+
+		Captions:          captionsEnabled,
+		SongVisualisation: songVisualisationEnabled,
+
+		RefillPolicy:        refillPolicyNames[refiller.policy],
+		SeedBagUsed:         1 - refiller.bag,
+		LastScheduledRefill: refiller.lastScheduledRefill,
+
+		Economy: economySettings{
+			Enabled:     economy.enabled,
+			Coins:       economy.coins,
+			SeenSpecies: economy.seenSpeciesNames(),
+			Unlocked:    economy.unlockedNames(),
+			SeedType:    economy.seedType,
+		},
+
+		Achievements:     achievements.earned,
+		FeederStockedFor: achievements.stockedFor,
+	}
+
+	for bus, mixerBus := range mixer.buses {
+		currentSettings.BusVolumes[audioBusNames[bus]] = mixerBus.volume
+		currentSettings.MutedBuses[audioBusNames[bus]] = mixerBus.muted
+	}
+
+	return currentSettings
+}
+
+// Remember the current settings for next time.
+func saveSettings() {
+	path, err := settingsPath()
+	if err != nil {
+		fmt.Println("could not find the settings file: " + err.Error())
+		return
+	}
+
+	contents, err := json.MarshalIndent(currentSettings(), "", "  ")
+	if err != nil {
+		fmt.Println("could not save the settings: " + err.Error())
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		fmt.Println("could not save the settings: " + err.Error())
+		return
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		fmt.Println("could not save the settings: " + err.Error())
+	}
+}
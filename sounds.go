@@ -1,87 +1,126 @@
 package main
 
 import (
-	"log"
+	"bytes"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/faiface/beep"
+	"github.com/faiface/beep/flac"
 	"github.com/faiface/beep/mp3"
-	"github.com/faiface/beep/speaker"
+	"github.com/faiface/beep/vorbis"
+	"github.com/faiface/beep/wav"
 )
 
-// Call as a go-routine.
-func playSound(buffer *beep.Buffer, completeFlag chan bool) {
-	streamer := buffer.Streamer(0, buffer.Len())
+// How long a buffered sound plays for. Missing sounds take no time at all.
+func soundLength(buffer *beep.Buffer) time.Duration {
+	if buffer == nil {
+		return 0
+	}
 
-	done := make(chan bool)
+	return speakerSampleRate.D(buffer.Len())
+}
 
-	speaker.Play(beep.Seq(streamer, beep.Callback(func() {
-		completeFlag <- true
-		done <- true
-	})))
+// Decode a sound file (MP3, WAV, OGG Vorbis or FLAC) into a buffer at the speaker's sample rate.
+func bufferSound(path string) (*beep.Buffer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 
-	<-done
-}
+	streamer, format, err := decodeSound(path, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	defer streamer.Close()
+
+	buffer := beep.NewBuffer(beep.Format{SampleRate: speakerSampleRate, NumChannels: format.NumChannels, Precision: format.Precision})
 
-// Call as a go-routine.
-func playLoopingSound(buffer *beep.Buffer, controller chan bool) {
-	for {
-		// Loop while sound is disabled. Exit if controller is closed.
-		if soundDisabled {
-			select {
-			case _, ok := <-controller:
-				if !ok {
-					return
-				}
-			default:
-			}
-
-			continue
-		}
-
-		streamer := buffer.Streamer(0, buffer.Len())
-
-		done := make(chan bool)
-
-		speaker.Play(beep.Seq(streamer, beep.Callback(func() {
-			done <- true
-		})))
-
-	playback:
-		for {
-			select {
-			case _, ok := <-controller:
-				if !ok {
-					return
-				}
-			case finished, ok := <-done:
-				if finished || !ok {
-					break playback
-				}
-			default:
-			}
-
-			if soundDisabled {
-				break playback
-			}
-		}
+	// Everything plays through the same speaker, so everything has to be at its sample rate.
+	if format.SampleRate != speakerSampleRate {
+		buffer.Append(beep.Resample(resampleQuality, format.SampleRate, speakerSampleRate, streamer))
+	} else {
+		buffer.Append(streamer)
 	}
+
+	return buffer, nil
 }
 
-func bufferSound(path string) *beep.Buffer {
-	f, err := os.Open(path)
+type soundFormat int
+
+const (
+	unknownFormat soundFormat = iota
+	mp3Format
+	wavFormat
+	vorbisFormat
+	flacFormat
+)
+
+var soundFormatExtensions = map[string]soundFormat{
+	".mp3":  mp3Format,
+	".wav":  wavFormat,
+	".wave": wavFormat,
+	".ogg":  vorbisFormat,
+	".oga":  vorbisFormat,
+	".flac": flacFormat,
+}
+
+func decodeSound(path string, f *os.File) (beep.StreamSeekCloser, beep.Format, error) {
+	// Trust the file's contents over its name.
+	format, err := sniffSoundFormat(f)
 	if err != nil {
-		log.Fatal(err)
+		return nil, beep.Format{}, err
 	}
 
-	streamer, format, err := mp3.Decode(f)
-	if err != nil {
-		log.Fatal(err)
+	if format == unknownFormat {
+		format = soundFormatExtensions[strings.ToLower(filepath.Ext(path))]
 	}
 
-	buffer := beep.NewBuffer(format)
-	buffer.Append(streamer)
-	streamer.Close()
+	switch format {
+	case mp3Format:
+		return mp3.Decode(f)
+	case wavFormat:
+		return wav.Decode(f)
+	case vorbisFormat:
+		return vorbis.Decode(f)
+	case flacFormat:
+		return flac.Decode(f)
+	}
+
+	return nil, beep.Format{}, errors.New("unsupported sound format: " + path)
+}
+
+// Work out a sound file's format from its first few bytes, leaving the file where it started.
+func sniffSoundFormat(f *os.File) (soundFormat, error) {
+	header := make([]byte, 12)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return unknownFormat, err
+	}
+	header = header[:n]
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return unknownFormat, err
+	}
+
+	switch {
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return wavFormat, nil
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return vorbisFormat, nil
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return flacFormat, nil
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return mp3Format, nil
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		// An MP3 frame sync, for files without an ID3 tag.
+		return mp3Format, nil
+	}
 
-	return buffer
+	return unknownFormat, nil
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/faiface/pixel"
+	wr "github.com/mroth/weightedrand"
+)
+
+// A bird that's only ever heard in the distance, never seen at the feeder (so it needs no sprite).
+type soundscapeVoice struct {
+	name     string
+	path     string
+	presence presence
+
+	// How the call is written down, for captions.
+	mnemonic string
+
+	// Likelihood (out of likelihoodMaxPercent) of this voice being picked, before the time of day and year.
+	likelihood uint
+
+	// Percentage of the likelihood to apply, by phase of the day.
+	dayPhasePercents map[dayPhase]uint
+}
+
+// Most birds call through the day, loudest in the morning.
+var diurnalCallPercents = map[dayPhase]uint{
+	night:     0,
+	dawn:      150,
+	morning:   120,
+	midday:    50,
+	afternoon: 80,
+	evening:   100,
+}
+
+// Owls and the like.
+var nocturnalCallPercents = map[dayPhase]uint{
+	night:     100,
+	dawn:      30,
+	morning:   0,
+	midday:    0,
+	afternoon: 0,
+	evening:   40,
+}
+
+// A distant call: the sound to play, and who it's from.
+type soundscapeCall struct {
+	sound    string
+	name     string
+	mnemonic string
+}
+
+// Schedules distant calls and songs from around the region, over the top of the ambience, so the yard sounds alive
+// even when the feeder is empty.
+type soundscapeGenerator struct {
+	nextCallTime time.Time
+}
+
+var soundscape = &soundscapeGenerator{}
+
+// Buffer the region's distant voices. They're optional, so any without a recording are quietly left out.
+func bufferSoundscapeSounds() {
+	for _, voice := range regionCatalogs[currentRegion].voices {
+		if _, err := os.Stat(voice.path); err != nil {
+			continue
+		}
+
+		buffer, err := bufferSound(voice.path)
+		if err != nil {
+			fmt.Println("warning: could not load sound " + voice.name + " from " + voice.path + ": " + err.Error())
+			continue
+		}
+
+		soundBuffers[voice.name] = buffer
+	}
+}
+
+func (soundscape *soundscapeGenerator) update() {
+	if time.Now().Before(soundscape.nextCallTime) {
+		return
+	}
+
+	soundscape.setNextCallTime()
+
+	if soundDisabled {
+		return
+	}
+
+	choices := soundscapeChoices(time.Now())
+	if len(choices) == 0 {
+		return
+	}
+
+	chooser, _ := wr.NewChooser(choices...)
+	call := chooser.Pick().(soundscapeCall)
+	buffer := soundBuffers[call.sound]
+	position := soundscapePosition()
+
+	captions.add(call.name, call.mnemonic, nil, position, soundLength(buffer))
+	visualiser.follow(call.name, buffer)
+	playPositionedSound(ambienceBus, buffer, position, nil)
+}
+
+// Calls come closer together when the birds are busiest, and further apart in bad weather.
+func (soundscape *soundscapeGenerator) setNextCallTime() {
+	gap := nextRandomInt(soundscapeGapRange.min, soundscapeGapRange.max)
+	gap = gap * dayPhaseSingingGapPercents[getDayPhase(time.Now())] / 100
+	gap = gap * 100 / int(weather.effect().activityPercent)
+
+	soundscape.nextCallTime = time.Now().Add(time.Second * time.Duration(gap))
+}
+
+// Every call that could be heard in the distance on the given date, weighted by how likely it is.
+// Anything around in the region can be heard, not only the birds that visit the feeder.
+func soundscapeChoices(date time.Time) []wr.Choice {
+	choices := []wr.Choice{}
+
+	for _, choice := range seasonalBirdChoices(date) {
+		species := choice.Item.(species)
+		weight := choice.Weight * singingPercent(date) / 100
+
+		if _, found := soundBuffers[species.Song()]; found && weight > 0 {
+			choices = append(choices, wr.Choice{Item: soundscapeCall{sound: species.Song(), name: species.Name(), mnemonic: species.SongMnemonic()}, Weight: weight})
+		}
+	}
+
+	for _, voice := range regionCatalogs[currentRegion].voices {
+		weight := voice.likelihood * presenceMonthlyPercents[voice.presence][date.Month()-1] / 100
+		weight = weight * voice.dayPhasePercents[getDayPhase(date)] / 100
+
+		if _, found := soundBuffers[voice.name]; found && weight > 0 {
+			choices = append(choices, wr.Choice{Item: soundscapeCall{sound: voice.name, name: voice.name, mnemonic: voice.mnemonic}, Weight: weight})
+		}
+	}
+
+	return choices
+}
+
+// Somewhere out of sight in any direction. How far away sets both how loud it is and where it's panned.
+func soundscapePosition() pixel.Vec {
+	distance := nextRandomFloat64(soundscapeMinDistance, soundscapeMaxDistance)
+	return listenerPosition().Add(pixel.V(distance, 0).Rotated(nextRandomFloat64(0, 2*math.Pi)))
+}
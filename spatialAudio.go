@@ -0,0 +1,138 @@
+package main
+
+import (
+	"math"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/faiface/beep/speaker"
+	"github.com/faiface/pixel"
+)
+
+// Where a sound sits in the stereo field, from -1 (left) to 1 (right), given where it is in the scene.
+func panForPosition(position pixel.Vec) float64 {
+	return math.Max(-1, math.Min(1, position.X/(winWidth/2))) * maxSongPan
+}
+
+// How loud a sound is, from 0 to 1, given where it is in the scene. Anything on the feeder is at full volume,
+// fading away with distance beyond that.
+func attenuationForPosition(position pixel.Vec) float64 {
+	distance := position.Sub(listenerPosition()).Len()
+	if distance <= songFullVolumeDistance {
+		return 1
+	}
+
+	return songFullVolumeDistance / distance
+}
+
+// Where the 'ears' are: the middle of what's on screen.
+func listenerPosition() pixel.Vec {
+	return context.Seed().center
+}
+
+// A sound coming from somewhere in (or beyond) the scene, panned and attenuated for wherever that is.
+type positionedSound struct {
+	control *beep.Ctrl
+	pan     *effects.Pan
+	volume  *effects.Volume
+
+	// Signalled once the sound has finished playing.
+	finished chan bool
+}
+
+// Play a sound from the given position, signalling the complete flag once it finishes.
+func playPositionedSound(bus audioBus, buffer *beep.Buffer, position pixel.Vec, completeFlag chan bool) *positionedSound {
+	// Missing sounds are silent, and finish straight away.
+	if buffer == nil {
+		signalComplete(completeFlag)
+		return nil
+	}
+
+	streamer := buffer.Streamer(0, buffer.Len())
+
+	sound := &positionedSound{finished: make(chan bool, 1)}
+	sound.control = &beep.Ctrl{Streamer: beep.Seq(streamer, beep.Callback(func() {
+		signalComplete(sound.finished)
+		signalComplete(completeFlag)
+	}))}
+	sound.pan = &effects.Pan{Streamer: sound.control}
+	sound.volume = &effects.Volume{Streamer: sound.pan, Base: 2}
+
+	speaker.Lock()
+	sound.applyPosition(position)
+	speaker.Unlock()
+
+	mixer.play(bus, sound.volume)
+
+	return sound
+}
+
+// Whether the sound has finished playing. Only reports it the once.
+func (sound *positionedSound) done() bool {
+	select {
+	case <-sound.finished:
+		return true
+	default:
+		return false
+	}
+}
+
+// Move the sound somewhere else, e.g. to follow a flying bird.
+func (sound *positionedSound) moveTo(position pixel.Vec) {
+	speaker.Lock()
+	sound.applyPosition(position)
+	speaker.Unlock()
+}
+
+// The speaker must be locked when this is called.
+func (sound *positionedSound) applyPosition(position pixel.Vec) {
+	sound.pan.Pan = panForPosition(position)
+
+	attenuation := attenuationForPosition(position)
+	sound.volume.Silent = attenuation == 0
+	sound.volume.Volume = math.Log2(math.Max(attenuation, minimumAttenuation))
+}
+
+// Play a song or call from wherever the bird is, panned and attenuated as it moves.
+func (bird *bird) playSong(buffer *beep.Buffer, completeFlag chan bool) {
+	bird.song = playPositionedSound(songsBus, buffer, bird.physics.rect.Center(), completeFlag)
+}
+
+// Let whoever is waiting know a sound has finished, without ever blocking (the speaker calls this while locked).
+func signalComplete(completeFlag chan bool) {
+	if completeFlag == nil {
+		return
+	}
+
+	select {
+	case completeFlag <- true:
+	default:
+	}
+}
+
+// Keep the bird's song coming from wherever the bird currently is, and let it go once it's over.
+func (bird *bird) updateSongPosition() {
+	if bird.song == nil {
+		return
+	}
+
+	if bird.song.done() {
+		bird.song = nil
+		return
+	}
+
+	bird.song.moveTo(bird.physics.rect.Center())
+}
+
+// Birds sometimes call as they fly in or out.
+func (bird *bird) maybeCall() {
+	if soundDisabled || nextRandomInt(0, likelihoodMaxPercent) >= flightCallLikelihood {
+		return
+	}
+
+	// Species without a call of their own stay quiet.
+	if buffer, found := soundBuffers[bird.species.Call()]; found {
+		announceCall(bird, buffer)
+		bird.playSong(buffer, nil)
+	}
+}
@@ -2,13 +2,22 @@ package main
 
 type species interface {
 	Initialize()
+	Name() string
 	ConsumptionRate() float64
 	Width() float64
 	Height() float64
 	FlightSpeed() float64
 	Song() string
+	SongMnemonic() string
+	Call() string
+	CallMnemonic() string
 	SingingLikelihood() uint
 	Animation() string
+	FlockSizeLikelihoods() map[int]uint
+	DominanceRank() int
+	Presence() map[region]presence
+	ResponseLikelihood() uint
+	CountersingingLength() int
 }
 
 // Northern Cardinal species.
@@ -18,8 +27,25 @@ type cardinal struct {
 	height            float64
 	flightSpeed       float64
 	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
 	singingLikelihood uint
 	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
 }
 
 func (cardinal *cardinal) Initialize() {
@@ -27,9 +53,26 @@ func (cardinal *cardinal) Initialize() {
 	cardinal.width = 190
 	cardinal.height = 221
 	cardinal.flightSpeed = 30
-	cardinal.songName = "Downy Woodpecker"
+	cardinal.songName = "Northern Cardinal"
+	cardinal.songMnemonic = "cheer-cheer-cheer"
+	cardinal.callName = "Northern Cardinal call"
+	cardinal.callMnemonic = "chip!"
 	cardinal.singingLikelihood = 100
 	cardinal.animation = "sprites/northernCardinal.png"
+	cardinal.flockSizeLikelihoods = map[int]uint{
+		1: 70,
+		2: 30,
+	}
+	cardinal.dominanceRank = 4
+	cardinal.presence = map[region]presence{
+		easternNorthAmerica: resident,
+	}
+	cardinal.responseLikelihood = 700
+	cardinal.countersingingLength = 6
+}
+
+func (cardinal *cardinal) Name() string {
+	return "Northern Cardinal"
 }
 
 func (cardinal *cardinal) ConsumptionRate() float64 {
@@ -56,10 +99,42 @@ func (cardinal *cardinal) Song() string {
 	return cardinal.songName
 }
 
+func (cardinal *cardinal) SongMnemonic() string {
+	return cardinal.songMnemonic
+}
+
+func (cardinal *cardinal) Call() string {
+	return cardinal.callName
+}
+
+func (cardinal *cardinal) CallMnemonic() string {
+	return cardinal.callMnemonic
+}
+
 func (cardinal *cardinal) SingingLikelihood() uint {
 	return cardinal.singingLikelihood
 }
 
+func (cardinal *cardinal) FlockSizeLikelihoods() map[int]uint {
+	return cardinal.flockSizeLikelihoods
+}
+
+func (cardinal *cardinal) DominanceRank() int {
+	return cardinal.dominanceRank
+}
+
+func (cardinal *cardinal) Presence() map[region]presence {
+	return cardinal.presence
+}
+
+func (cardinal *cardinal) ResponseLikelihood() uint {
+	return cardinal.responseLikelihood
+}
+
+func (cardinal *cardinal) CountersingingLength() int {
+	return cardinal.countersingingLength
+}
+
 // Downy Woodpecker species.
 type downyWoodpecker struct {
 	consumptionRate   float64
@@ -67,8 +142,25 @@ type downyWoodpecker struct {
 	height            float64
 	flightSpeed       float64
 	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
 	singingLikelihood uint
 	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
 }
 
 func (downyWoodpecker *downyWoodpecker) Initialize() {
@@ -77,8 +169,25 @@ func (downyWoodpecker *downyWoodpecker) Initialize() {
 	downyWoodpecker.height = 221
 	downyWoodpecker.flightSpeed = 30
 	downyWoodpecker.songName = "Downy Woodpecker"
+	downyWoodpecker.songMnemonic = "pik! ...ki-ki-ki-ki-ki"
+	downyWoodpecker.callName = "Downy Woodpecker call"
+	downyWoodpecker.callMnemonic = "pik!"
 	downyWoodpecker.singingLikelihood = 30
 	downyWoodpecker.animation = "sprites/downyWoodpecker.png"
+	downyWoodpecker.flockSizeLikelihoods = map[int]uint{
+		1: 100,
+	}
+	downyWoodpecker.dominanceRank = 3
+	downyWoodpecker.presence = map[region]presence{
+		easternNorthAmerica: resident,
+		pacificNorthwest:    resident,
+	}
+	downyWoodpecker.responseLikelihood = 200
+	downyWoodpecker.countersingingLength = 1
+}
+
+func (downyWoodpecker *downyWoodpecker) Name() string {
+	return "Downy Woodpecker"
 }
 
 func (downyWoodpecker *downyWoodpecker) ConsumptionRate() float64 {
@@ -101,6 +210,18 @@ func (downyWoodpecker *downyWoodpecker) Song() string {
 	return downyWoodpecker.songName
 }
 
+func (downyWoodpecker *downyWoodpecker) SongMnemonic() string {
+	return downyWoodpecker.songMnemonic
+}
+
+func (downyWoodpecker *downyWoodpecker) Call() string {
+	return downyWoodpecker.callName
+}
+
+func (downyWoodpecker *downyWoodpecker) CallMnemonic() string {
+	return downyWoodpecker.callMnemonic
+}
+
 func (downyWoodpecker *downyWoodpecker) Animation() string {
 	return downyWoodpecker.animation
 }
@@ -109,6 +230,26 @@ func (downyWoodpecker *downyWoodpecker) SingingLikelihood() uint {
 	return downyWoodpecker.singingLikelihood
 }
 
+func (downyWoodpecker *downyWoodpecker) FlockSizeLikelihoods() map[int]uint {
+	return downyWoodpecker.flockSizeLikelihoods
+}
+
+func (downyWoodpecker *downyWoodpecker) DominanceRank() int {
+	return downyWoodpecker.dominanceRank
+}
+
+func (downyWoodpecker *downyWoodpecker) Presence() map[region]presence {
+	return downyWoodpecker.presence
+}
+
+func (downyWoodpecker *downyWoodpecker) ResponseLikelihood() uint {
+	return downyWoodpecker.responseLikelihood
+}
+
+func (downyWoodpecker *downyWoodpecker) CountersingingLength() int {
+	return downyWoodpecker.countersingingLength
+}
+
 // Black-capped Chickadee species.
 type chickadee struct {
 	consumptionRate   float64
@@ -116,8 +257,25 @@ type chickadee struct {
 	height            float64
 	flightSpeed       float64
 	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
 	singingLikelihood uint
 	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
 }
 
 func (chickadee *chickadee) Initialize() {
@@ -125,9 +283,29 @@ func (chickadee *chickadee) Initialize() {
 	chickadee.width = 190
 	chickadee.height = 221
 	chickadee.flightSpeed = 30
-	chickadee.songName = "Downy Woodpecker"
+	chickadee.songName = "Black-capped Chickadee"
+	chickadee.songMnemonic = "fee-bee"
+	chickadee.callName = "Black-capped Chickadee call"
+	chickadee.callMnemonic = "chick-a-dee-dee-dee"
 	chickadee.singingLikelihood = 100
 	chickadee.animation = "sprites/blackCappedChickadee.png"
+	chickadee.flockSizeLikelihoods = map[int]uint{
+		1: 40,
+		2: 30,
+		3: 20,
+		4: 10,
+	}
+	chickadee.dominanceRank = 1
+	chickadee.presence = map[region]presence{
+		easternNorthAmerica: resident,
+		pacificNorthwest:    resident,
+	}
+	chickadee.responseLikelihood = 500
+	chickadee.countersingingLength = 2
+}
+
+func (chickadee *chickadee) Name() string {
+	return "Black-capped Chickadee"
 }
 
 func (chickadee *chickadee) ConsumptionRate() float64 {
@@ -150,6 +328,18 @@ func (chickadee *chickadee) Song() string {
 	return chickadee.songName
 }
 
+func (chickadee *chickadee) SongMnemonic() string {
+	return chickadee.songMnemonic
+}
+
+func (chickadee *chickadee) Call() string {
+	return chickadee.callName
+}
+
+func (chickadee *chickadee) CallMnemonic() string {
+	return chickadee.callMnemonic
+}
+
 func (chickadee *chickadee) Animation() string {
 	return chickadee.animation
 }
@@ -158,6 +348,26 @@ func (chickadee *chickadee) SingingLikelihood() uint {
 	return chickadee.singingLikelihood
 }
 
+func (chickadee *chickadee) FlockSizeLikelihoods() map[int]uint {
+	return chickadee.flockSizeLikelihoods
+}
+
+func (chickadee *chickadee) DominanceRank() int {
+	return chickadee.dominanceRank
+}
+
+func (chickadee *chickadee) Presence() map[region]presence {
+	return chickadee.presence
+}
+
+func (chickadee *chickadee) ResponseLikelihood() uint {
+	return chickadee.responseLikelihood
+}
+
+func (chickadee *chickadee) CountersingingLength() int {
+	return chickadee.countersingingLength
+}
+
 // Tufted Titmouse species.
 type titmouse struct {
 	consumptionRate   float64
@@ -165,8 +375,25 @@ type titmouse struct {
 	height            float64
 	flightSpeed       float64
 	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
 	singingLikelihood uint
 	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
 }
 
 func (titmouse *titmouse) Initialize() {
@@ -174,9 +401,26 @@ func (titmouse *titmouse) Initialize() {
 	titmouse.width = 190
 	titmouse.height = 221
 	titmouse.flightSpeed = 30
-	titmouse.songName = "Downy Woodpecker"
+	titmouse.songName = "Tufted Titmouse"
+	titmouse.songMnemonic = "peter-peter-peter"
+	titmouse.callName = "Tufted Titmouse call"
+	titmouse.callMnemonic = "tsee-day-day-day"
 	titmouse.singingLikelihood = 80
 	titmouse.animation = "sprites/tuftedTitmouse.png"
+	titmouse.flockSizeLikelihoods = map[int]uint{
+		1: 60,
+		2: 40,
+	}
+	titmouse.dominanceRank = 2
+	titmouse.presence = map[region]presence{
+		easternNorthAmerica: resident,
+	}
+	titmouse.responseLikelihood = 400
+	titmouse.countersingingLength = 2
+}
+
+func (titmouse *titmouse) Name() string {
+	return "Tufted Titmouse"
 }
 
 func (titmouse *titmouse) ConsumptionRate() float64 {
@@ -199,6 +443,18 @@ func (titmouse *titmouse) Song() string {
 	return titmouse.songName
 }
 
+func (titmouse *titmouse) SongMnemonic() string {
+	return titmouse.songMnemonic
+}
+
+func (titmouse *titmouse) Call() string {
+	return titmouse.callName
+}
+
+func (titmouse *titmouse) CallMnemonic() string {
+	return titmouse.callMnemonic
+}
+
 func (titmouse *titmouse) Animation() string {
 	return titmouse.animation
 }
@@ -206,3 +462,496 @@ func (titmouse *titmouse) Animation() string {
 func (titmouse *titmouse) SingingLikelihood() uint {
 	return titmouse.singingLikelihood
 }
+
+func (titmouse *titmouse) FlockSizeLikelihoods() map[int]uint {
+	return titmouse.flockSizeLikelihoods
+}
+
+func (titmouse *titmouse) DominanceRank() int {
+	return titmouse.dominanceRank
+}
+
+func (titmouse *titmouse) Presence() map[region]presence {
+	return titmouse.presence
+}
+
+func (titmouse *titmouse) ResponseLikelihood() uint {
+	return titmouse.responseLikelihood
+}
+
+func (titmouse *titmouse) CountersingingLength() int {
+	return titmouse.countersingingLength
+}
+
+// Dark-eyed Junco species.
+type junco struct {
+	consumptionRate   float64
+	width             float64
+	height            float64
+	flightSpeed       float64
+	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
+	singingLikelihood uint
+	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
+}
+
+func (junco *junco) Initialize() {
+	junco.consumptionRate = .003
+	junco.width = 190
+	junco.height = 221
+	junco.flightSpeed = 30
+	junco.songName = "Dark-eyed Junco"
+	junco.songMnemonic = "trrrrrrrrrr"
+	junco.callName = "Dark-eyed Junco call"
+	junco.callMnemonic = "tick! tick!"
+	junco.singingLikelihood = 40
+	junco.animation = "sprites/darkEyedJunco.png"
+	junco.flockSizeLikelihoods = map[int]uint{
+		1: 30,
+		2: 30,
+		3: 25,
+		4: 15,
+	}
+	junco.dominanceRank = 2
+
+	// Juncos spend the winter in the east, but stay all year in the Northwest.
+	junco.presence = map[region]presence{
+		easternNorthAmerica: winterVisitor,
+		pacificNorthwest:    resident,
+	}
+	junco.responseLikelihood = 300
+	junco.countersingingLength = 1
+}
+
+func (junco *junco) Name() string {
+	return "Dark-eyed Junco"
+}
+
+func (junco *junco) ConsumptionRate() float64 {
+	return junco.consumptionRate
+}
+
+func (junco *junco) Width() float64 {
+	return junco.width
+}
+
+func (junco *junco) Height() float64 {
+	return junco.height
+}
+
+func (junco *junco) FlightSpeed() float64 {
+	return junco.flightSpeed
+}
+
+func (junco *junco) Song() string {
+	return junco.songName
+}
+
+func (junco *junco) SongMnemonic() string {
+	return junco.songMnemonic
+}
+
+func (junco *junco) Call() string {
+	return junco.callName
+}
+
+func (junco *junco) CallMnemonic() string {
+	return junco.callMnemonic
+}
+
+func (junco *junco) Animation() string {
+	return junco.animation
+}
+
+func (junco *junco) SingingLikelihood() uint {
+	return junco.singingLikelihood
+}
+
+func (junco *junco) FlockSizeLikelihoods() map[int]uint {
+	return junco.flockSizeLikelihoods
+}
+
+func (junco *junco) DominanceRank() int {
+	return junco.dominanceRank
+}
+
+func (junco *junco) Presence() map[region]presence {
+	return junco.presence
+}
+
+// Yellow-rumped Warbler species.
+type warbler struct {
+	consumptionRate   float64
+	width             float64
+	height            float64
+	flightSpeed       float64
+	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
+	singingLikelihood uint
+	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
+}
+
+func (warbler *warbler) Initialize() {
+	warbler.consumptionRate = .002
+	warbler.width = 190
+	warbler.height = 221
+	warbler.flightSpeed = 32
+	warbler.songName = "Yellow-rumped Warbler"
+	warbler.songMnemonic = "seet-seet-seet-trrrr"
+	warbler.callName = "Yellow-rumped Warbler call"
+	warbler.callMnemonic = "check!"
+	warbler.singingLikelihood = 60
+	warbler.animation = "sprites/yellowRumpedWarbler.png"
+	warbler.flockSizeLikelihoods = map[int]uint{
+		1: 50,
+		2: 35,
+		3: 15,
+	}
+	warbler.dominanceRank = 1
+
+	// Warblers only pass through, in spring on the way north and in autumn on the way back.
+	warbler.presence = map[region]presence{
+		easternNorthAmerica: passageMigrant,
+		pacificNorthwest:    passageMigrant,
+	}
+	warbler.responseLikelihood = 200
+	warbler.countersingingLength = 1
+}
+
+func (warbler *warbler) Name() string {
+	return "Yellow-rumped Warbler"
+}
+
+func (warbler *warbler) ConsumptionRate() float64 {
+	return warbler.consumptionRate
+}
+
+func (warbler *warbler) Width() float64 {
+	return warbler.width
+}
+
+func (warbler *warbler) Height() float64 {
+	return warbler.height
+}
+
+func (warbler *warbler) FlightSpeed() float64 {
+	return warbler.flightSpeed
+}
+
+func (warbler *warbler) Song() string {
+	return warbler.songName
+}
+
+func (warbler *warbler) SongMnemonic() string {
+	return warbler.songMnemonic
+}
+
+func (warbler *warbler) Call() string {
+	return warbler.callName
+}
+
+func (warbler *warbler) CallMnemonic() string {
+	return warbler.callMnemonic
+}
+
+func (warbler *warbler) Animation() string {
+	return warbler.animation
+}
+
+func (warbler *warbler) SingingLikelihood() uint {
+	return warbler.singingLikelihood
+}
+
+func (warbler *warbler) FlockSizeLikelihoods() map[int]uint {
+	return warbler.flockSizeLikelihoods
+}
+
+func (warbler *warbler) DominanceRank() int {
+	return warbler.dominanceRank
+}
+
+func (warbler *warbler) Presence() map[region]presence {
+	return warbler.presence
+}
+
+func (junco *junco) ResponseLikelihood() uint {
+	return junco.responseLikelihood
+}
+
+func (junco *junco) CountersingingLength() int {
+	return junco.countersingingLength
+}
+
+func (warbler *warbler) ResponseLikelihood() uint {
+	return warbler.responseLikelihood
+}
+
+func (warbler *warbler) CountersingingLength() int {
+	return warbler.countersingingLength
+}
+
+// Great Tit species.
+type greatTit struct {
+	consumptionRate   float64
+	width             float64
+	height            float64
+	flightSpeed       float64
+	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
+	singingLikelihood uint
+	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
+}
+
+func (greatTit *greatTit) Initialize() {
+	greatTit.consumptionRate = .003
+	greatTit.width = 190
+	greatTit.height = 221
+	greatTit.flightSpeed = 30
+	greatTit.songName = "Great Tit"
+	greatTit.songMnemonic = "teacher-teacher-teacher"
+	greatTit.callName = "Great Tit call"
+	greatTit.callMnemonic = "pink pink"
+	greatTit.singingLikelihood = 100
+	greatTit.animation = "sprites/greatTit.png"
+	greatTit.flockSizeLikelihoods = map[int]uint{
+		1: 60,
+		2: 40,
+	}
+	greatTit.dominanceRank = 2
+	greatTit.presence = map[region]presence{
+		unitedKingdom: resident,
+		centralEurope: resident,
+	}
+	greatTit.responseLikelihood = 600
+	greatTit.countersingingLength = 4
+}
+
+func (greatTit *greatTit) Name() string {
+	return "Great Tit"
+}
+
+func (greatTit *greatTit) ConsumptionRate() float64 {
+	return greatTit.consumptionRate
+}
+
+func (greatTit *greatTit) Width() float64 {
+	return greatTit.width
+}
+
+func (greatTit *greatTit) Height() float64 {
+	return greatTit.height
+}
+
+func (greatTit *greatTit) FlightSpeed() float64 {
+	return greatTit.flightSpeed
+}
+
+func (greatTit *greatTit) Song() string {
+	return greatTit.songName
+}
+
+func (greatTit *greatTit) SongMnemonic() string {
+	return greatTit.songMnemonic
+}
+
+func (greatTit *greatTit) Call() string {
+	return greatTit.callName
+}
+
+func (greatTit *greatTit) CallMnemonic() string {
+	return greatTit.callMnemonic
+}
+
+func (greatTit *greatTit) Animation() string {
+	return greatTit.animation
+}
+
+func (greatTit *greatTit) SingingLikelihood() uint {
+	return greatTit.singingLikelihood
+}
+
+func (greatTit *greatTit) FlockSizeLikelihoods() map[int]uint {
+	return greatTit.flockSizeLikelihoods
+}
+
+func (greatTit *greatTit) DominanceRank() int {
+	return greatTit.dominanceRank
+}
+
+func (greatTit *greatTit) Presence() map[region]presence {
+	return greatTit.presence
+}
+
+func (greatTit *greatTit) ResponseLikelihood() uint {
+	return greatTit.responseLikelihood
+}
+
+func (greatTit *greatTit) CountersingingLength() int {
+	return greatTit.countersingingLength
+}
+
+// Blue Tit species.
+type blueTit struct {
+	consumptionRate   float64
+	width             float64
+	height            float64
+	flightSpeed       float64
+	songName          string
+	songMnemonic      string
+	callName          string
+	callMnemonic      string
+	singingLikelihood uint
+	animation         string
+
+	// How many birds of this species tend to show up together.
+	flockSizeLikelihoods map[int]uint
+
+	// Birds can chase lower ranked species off a perch.
+	dominanceRank int
+
+	// When this species is around, by region.
+	presence map[region]presence
+
+	// Likelihood (out of likelihoodMaxPercent) of answering another of its kind, and how many songs back and forth
+	// a bout of countersinging can run to.
+	responseLikelihood   uint
+	countersingingLength int
+}
+
+func (blueTit *blueTit) Initialize() {
+	blueTit.consumptionRate = .004
+	blueTit.width = 190
+	blueTit.height = 221
+	blueTit.flightSpeed = 30
+	blueTit.songName = "Blue Tit"
+	blueTit.songMnemonic = "tsee-tsee-tsu-huhuhu"
+	blueTit.callName = "Blue Tit call"
+	blueTit.callMnemonic = "tsee-tsee-chur-r-r"
+	blueTit.singingLikelihood = 80
+	blueTit.animation = "sprites/blueTit.png"
+	blueTit.flockSizeLikelihoods = map[int]uint{
+		1: 40,
+		2: 30,
+		3: 20,
+		4: 10,
+	}
+	blueTit.dominanceRank = 1
+	blueTit.presence = map[region]presence{
+		unitedKingdom: resident,
+		centralEurope: resident,
+	}
+	blueTit.responseLikelihood = 400
+	blueTit.countersingingLength = 2
+}
+
+func (blueTit *blueTit) Name() string {
+	return "Blue Tit"
+}
+
+func (blueTit *blueTit) ConsumptionRate() float64 {
+	return blueTit.consumptionRate
+}
+
+func (blueTit *blueTit) Width() float64 {
+	return blueTit.width
+}
+
+func (blueTit *blueTit) Height() float64 {
+	return blueTit.height
+}
+
+func (blueTit *blueTit) FlightSpeed() float64 {
+	return blueTit.flightSpeed
+}
+
+func (blueTit *blueTit) Song() string {
+	return blueTit.songName
+}
+
+func (blueTit *blueTit) SongMnemonic() string {
+	return blueTit.songMnemonic
+}
+
+func (blueTit *blueTit) Call() string {
+	return blueTit.callName
+}
+
+func (blueTit *blueTit) CallMnemonic() string {
+	return blueTit.callMnemonic
+}
+
+func (blueTit *blueTit) Animation() string {
+	return blueTit.animation
+}
+
+func (blueTit *blueTit) SingingLikelihood() uint {
+	return blueTit.singingLikelihood
+}
+
+func (blueTit *blueTit) FlockSizeLikelihoods() map[int]uint {
+	return blueTit.flockSizeLikelihoods
+}
+
+func (blueTit *blueTit) DominanceRank() int {
+	return blueTit.dominanceRank
+}
+
+func (blueTit *blueTit) Presence() map[region]presence {
+	return blueTit.presence
+}
+
+func (blueTit *blueTit) ResponseLikelihood() uint {
+	return blueTit.responseLikelihood
+}
+
+func (blueTit *blueTit) CountersingingLength() int {
+	return blueTit.countersingingLength
+}
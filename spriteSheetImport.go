@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/faiface/pixel"
+)
+
+type animationFormat int
+
+const (
+	nativeAnimationFormat animationFormat = iota
+	asepriteFormat
+	texturePackerFormat
+)
+
+// Frame tags (or TexturePacker animation names) that mean one of the clips birdAnimation plays. Anything else keeps
+// its own name.
+var animationClipAliases = map[string]string{
+	"perch":   "Perch",
+	"perched": "Perch",
+	"idle":    "Perch",
+	"stand":   "Perch",
+	"eat":     "Eat",
+	"eating":  "Eat",
+	"feed":    "Eat",
+	"peck":    "Eat",
+	"sing":    "Sing",
+	"singing": "Sing",
+	"song":    "Sing",
+	"fly":     "Fly",
+	"flying":  "Fly",
+	"flight":  "Fly",
+	"flap":    "Fly",
+}
+
+// Tags with this prefix mark events instead of clips, e.g. a one frame "event:peck" tag.
+const animationEventTagPrefix = "event:"
+
+func clipNameForTag(tag string) string {
+	if name, found := animationClipAliases[strings.ToLower(strings.TrimSpace(tag))]; found {
+		return name
+	}
+
+	return strings.TrimSpace(tag)
+}
+
+// The parts of an Aseprite or TexturePacker export we need to tell them apart.
+type spriteSheetMeta struct {
+	Meta struct {
+		App string `json:"app"`
+	} `json:"meta"`
+	Clips json.RawMessage `json:"clips"`
+}
+
+func sniffAnimationFormat(contents []byte) animationFormat {
+	meta := spriteSheetMeta{}
+	if err := json.Unmarshal(contents, &meta); err != nil || meta.Clips != nil {
+		return nativeAnimationFormat
+	}
+
+	app := strings.ToLower(meta.Meta.App)
+	switch {
+	case strings.Contains(app, "aseprite"):
+		return asepriteFormat
+	case strings.Contains(app, "texturepacker"), strings.Contains(app, "codeandweb"):
+		return texturePackerFormat
+	}
+
+	return nativeAnimationFormat
+}
+
+// A frame as both Aseprite and TexturePacker export it. Durations (Aseprite only) are in milliseconds. Trimmed
+// frames give the part of the original frame they kept (spriteSourceSize) and the original's size (sourceSize).
+type spriteSheetFrame struct {
+	Filename         string            `json:"filename"`
+	Frame            animationFileRect `json:"frame"`
+	Rotated          bool              `json:"rotated"`
+	Trimmed          bool              `json:"trimmed"`
+	SpriteSourceSize animationFileRect `json:"spriteSourceSize"`
+	SourceSize       struct {
+		W float64 `json:"w"`
+		H float64 `json:"h"`
+	} `json:"sourceSize"`
+	Duration float64 `json:"duration"`
+}
+
+// Where the trimmed frame sat in the original: the original's size, and how far the middle of the kept part is from
+// the middle of the original (with y up, like the sheet).
+func (frame *spriteSheetFrame) trim() (pixel.Vec, pixel.Vec) {
+	if !frame.Trimmed || frame.SourceSize.W <= 0 || frame.SourceSize.H <= 0 {
+		return pixel.ZV, pixel.ZV
+	}
+
+	kept := frame.SpriteSourceSize
+	return pixel.V(frame.SourceSize.W, frame.SourceSize.H), pixel.V(
+		kept.X+kept.W/2-frame.SourceSize.W/2,
+		frame.SourceSize.H/2-(kept.Y+kept.H/2),
+	)
+}
+
+type asepriteSheet struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		FrameTags []asepriteFrameTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+type asepriteFrameTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+	Repeat    string `json:"repeat"`
+}
+
+// Each frame tag becomes a clip, played in the tag's direction.
+func parseAsepriteSheet(contents []byte, sheetBounds pixel.Rect) (map[string]*animationClip, error) {
+	sheet := asepriteSheet{}
+	if err := json.Unmarshal(contents, &sheet); err != nil {
+		return nil, err
+	}
+
+	namedFrames, err := decodeSpriteSheetFrames(sheet.Frames, sheetBounds)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := []animationFrame{}
+	for _, frame := range namedFrames {
+		frames = append(frames, frame.animationFrame)
+	}
+
+	if len(sheet.Meta.FrameTags) == 0 {
+		return nil, errors.New("no frame tags to make clips from")
+	}
+
+	// Event tags go on their frames first, so every clip using those frames picks them up.
+	for _, tag := range sheet.Meta.FrameTags {
+		if !strings.HasPrefix(strings.ToLower(tag.Name), animationEventTagPrefix) {
+			continue
+		}
+
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("tag %s is outside the frames", tag.Name)
+		}
+
+		for index := tag.From; index <= tag.To; index++ {
+			frames[index].events = append(frames[index].events, tag.Name[len(animationEventTagPrefix):])
+		}
+	}
+
+	clips := map[string]*animationClip{}
+	for _, tag := range sheet.Meta.FrameTags {
+		if strings.HasPrefix(strings.ToLower(tag.Name), animationEventTagPrefix) {
+			continue
+		}
+
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("tag %s is outside the frames", tag.Name)
+		}
+
+		clip := &animationClip{mode: loopPlayback}
+		clip.frames = append([]animationFrame{}, frames[tag.From:tag.To+1]...)
+
+		switch strings.ToLower(tag.Direction) {
+		case "reverse":
+			reverseFrames(clip.frames)
+		case "pingpong":
+			clip.mode = pingPongPlayback
+		case "pingpong_reverse":
+			reverseFrames(clip.frames)
+			clip.mode = pingPongPlayback
+		}
+
+		if tag.Repeat == "1" {
+			clip.mode = oncePlayback
+		}
+
+		clips[clipNameForTag(tag.Name)] = clip
+	}
+
+	return clips, nil
+}
+
+type texturePackerSheet struct {
+	Frames     json.RawMessage     `json:"frames"`
+	Animations map[string][]string `json:"animations"`
+}
+
+// TexturePacker has no tags, so frames are grouped into clips by name: "fly_0.png", "fly_1.png" and so on make up
+// "Fly". If the export lists its animations, those are used instead. Every frame plays at the default frame rate.
+func parseTexturePackerSheet(contents []byte, sheetBounds pixel.Rect) (map[string]*animationClip, error) {
+	sheet := texturePackerSheet{}
+	if err := json.Unmarshal(contents, &sheet); err != nil {
+		return nil, err
+	}
+
+	frames, err := decodeSpriteSheetFrames(sheet.Frames, sheetBounds)
+	if err != nil {
+		return nil, err
+	}
+
+	framesByName := map[string]animationFrame{}
+	for _, frame := range frames {
+		framesByName[frame.name] = frame.animationFrame
+	}
+
+	clips := map[string]*animationClip{}
+	if len(sheet.Animations) > 0 {
+		for name, frameNames := range sheet.Animations {
+			clip := &animationClip{mode: loopPlayback}
+			for _, frameName := range frameNames {
+				frame, found := framesByName[frameName]
+				if !found {
+					return nil, fmt.Errorf("animation %s uses a missing frame %s", name, frameName)
+				}
+
+				clip.frames = append(clip.frames, frame)
+			}
+
+			clips[clipNameForTag(name)] = clip
+		}
+
+		return clips, nil
+	}
+
+	// Group the frames by name, in numeric order.
+	groups := map[string][]namedAnimationFrame{}
+	for _, frame := range frames {
+		name, _ := splitFrameName(frame.name)
+		groups[name] = append(groups[name], frame)
+	}
+
+	for name, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			_, first := splitFrameName(group[i].name)
+			_, second := splitFrameName(group[j].name)
+			return first < second
+		})
+
+		clip := &animationClip{mode: loopPlayback}
+		for _, frame := range group {
+			clip.frames = append(clip.frames, frame.animationFrame)
+		}
+
+		clips[clipNameForTag(name)] = clip
+	}
+
+	return clips, nil
+}
+
+var frameNumberPattern = regexp.MustCompile(`^(.*?)[\s_\-./]*(\d+)$`)
+
+// Split a frame name like "Fly_02.png" into its clip name and number ("Fly" and 2).
+func splitFrameName(filename string) (string, int) {
+	name := filename
+	if dot := strings.LastIndex(name, "."); dot > 0 {
+		name = name[:dot]
+	}
+
+	matches := frameNumberPattern.FindStringSubmatch(name)
+	if matches == nil {
+		return name, 0
+	}
+
+	number, _ := strconv.Atoi(matches[2])
+	return matches[1], number
+}
+
+type namedAnimationFrame struct {
+	animationFrame
+	name string
+}
+
+// Frames are exported either as an array, or as an object keyed by filename. Objects are read in the order they're
+// written, since that's the order tags refer to them by.
+func decodeSpriteSheetFrames(raw json.RawMessage, sheetBounds pixel.Rect) ([]namedAnimationFrame, error) {
+	exported := []spriteSheetFrame{}
+
+	switch trimmed := bytes.TrimSpace(raw); {
+	case len(trimmed) == 0:
+		return nil, errors.New("no frames")
+	case trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &exported); err != nil {
+			return nil, err
+		}
+	case trimmed[0] == '{':
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			frame := spriteSheetFrame{}
+			if err := decoder.Decode(&frame); err != nil {
+				return nil, err
+			}
+
+			frame.Filename = fmt.Sprint(key)
+			exported = append(exported, frame)
+		}
+	default:
+		return nil, errors.New("frames must be an array or an object")
+	}
+
+	frames := []namedAnimationFrame{}
+	for _, frame := range exported {
+		// Rotated frames would need drawing rotated back, which sprites can't do from a rectangle alone.
+		if frame.Rotated {
+			return nil, fmt.Errorf("frame %s is rotated; export the sheet without rotation", frame.Filename)
+		}
+
+		rect := frame.Frame.sheetRect(sheetBounds)
+		if !rectWithin(rect, sheetBounds) {
+			return nil, fmt.Errorf("frame %s is outside the sprite sheet", frame.Filename)
+		}
+
+		duration := frame.Duration / 1000
+		if duration <= 0 {
+			duration = defaultBirdFrameRate
+		}
+
+		untrimmedSize, trimOffset := frame.trim()
+		frames = append(frames, namedAnimationFrame{
+			animationFrame: animationFrame{rect: rect, duration: duration, untrimmedSize: untrimmedSize, trimOffset: trimOffset},
+			name:           frame.Filename,
+		})
+	}
+
+	return frames, nil
+}
+
+func reverseFrames(frames []animationFrame) {
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+}
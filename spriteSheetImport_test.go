@@ -0,0 +1,256 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faiface/pixel"
+)
+
+// Two 43 pixel frames side by side, as exported.
+var testSheetBounds = pixel.R(0, 0, 86, 43)
+
+func TestParseAsepriteSheet(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]*animationClip
+		wantErr  bool
+	}{
+		{
+			name: "tags become clips",
+			contents: `{
+				"frames": [
+					{"filename": "bird 0.aseprite", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "duration": 100},
+					{"filename": "bird 1.aseprite", "frame": {"x": 43, "y": 0, "w": 43, "h": 43}, "duration": 250}
+				],
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": [
+					{"name": "idle", "from": 0, "to": 0, "direction": "forward"},
+					{"name": "Fly", "from": 0, "to": 1, "direction": "pingpong"},
+					{"name": "land", "from": 0, "to": 1, "direction": "reverse", "repeat": "1"}
+				]}
+			}`,
+			want: map[string]*animationClip{
+				"Perch": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 0, 43, 43), duration: .1},
+				}},
+				"Fly": {mode: pingPongPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 0, 43, 43), duration: .1},
+					{rect: pixel.R(43, 0, 86, 43), duration: .25},
+				}},
+				"land": {mode: oncePlayback, frames: []animationFrame{
+					{rect: pixel.R(43, 0, 86, 43), duration: .25},
+					{rect: pixel.R(0, 0, 43, 43), duration: .1},
+				}},
+			},
+		},
+		{
+			name: "event tags mark their frames",
+			contents: `{
+				"frames": {
+					"bird 0.aseprite": {"frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "duration": 100},
+					"bird 1.aseprite": {"frame": {"x": 43, "y": 0, "w": 43, "h": 43}, "duration": 100}
+				},
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": [
+					{"name": "Eat", "from": 0, "to": 1, "direction": "forward"},
+					{"name": "event:peck", "from": 1, "to": 1, "direction": "forward"}
+				]}
+			}`,
+			want: map[string]*animationClip{
+				"Eat": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 0, 43, 43), duration: .1},
+					{rect: pixel.R(43, 0, 86, 43), duration: .1, events: []string{"peck"}},
+				}},
+			},
+		},
+		{
+			name: "trimmed frames keep their place",
+			contents: `{
+				"frames": [
+					{"filename": "bird 0.aseprite", "frame": {"x": 0, "y": 0, "w": 20, "h": 30}, "trimmed": true,
+						"spriteSourceSize": {"x": 3, "y": 10, "w": 20, "h": 30}, "sourceSize": {"w": 43, "h": 43}, "duration": 100}
+				],
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": [{"name": "Perch", "from": 0, "to": 0}]}
+			}`,
+			want: map[string]*animationClip{
+				"Perch": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 13, 20, 43), duration: .1, untrimmedSize: pixel.V(43, 43), trimOffset: pixel.V(-8.5, -3.5)},
+				}},
+			},
+		},
+		{
+			name: "no tags",
+			contents: `{
+				"frames": [{"filename": "bird 0.aseprite", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "duration": 100}],
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": []}
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "tag past the last frame",
+			contents: `{
+				"frames": [{"filename": "bird 0.aseprite", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "duration": 100}],
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": [{"name": "Fly", "from": 0, "to": 3}]}
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "frame off the sheet",
+			contents: `{
+				"frames": [{"filename": "bird 0.aseprite", "frame": {"x": 80, "y": 0, "w": 43, "h": 43}, "duration": 100}],
+				"meta": {"app": "https://www.aseprite.org/", "frameTags": [{"name": "Fly", "from": 0, "to": 0}]}
+			}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if format := sniffAnimationFormat([]byte(test.contents)); format != asepriteFormat {
+				t.Fatalf("sniffed format %v, want Aseprite", format)
+			}
+
+			clips, err := parseAsepriteSheet([]byte(test.contents), testSheetBounds)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(clips, test.want) {
+				t.Errorf("got %+v, want %+v", clips, test.want)
+			}
+		})
+	}
+}
+
+func TestParseTexturePackerSheet(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]*animationClip
+		wantErr  bool
+	}{
+		{
+			name: "frames grouped by name, in numeric order",
+			contents: `{
+				"frames": {
+					"fly_10.png": {"frame": {"x": 43, "y": 0, "w": 43, "h": 43}},
+					"fly_2.png": {"frame": {"x": 0, "y": 0, "w": 43, "h": 43}},
+					"eat.png": {"frame": {"x": 0, "y": 0, "w": 43, "h": 43}}
+				},
+				"meta": {"app": "https://www.codeandweb.com/texturepacker"}
+			}`,
+			want: map[string]*animationClip{
+				"Fly": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 0, 43, 43), duration: defaultBirdFrameRate},
+					{rect: pixel.R(43, 0, 86, 43), duration: defaultBirdFrameRate},
+				}},
+				"Eat": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(0, 0, 43, 43), duration: defaultBirdFrameRate},
+				}},
+			},
+		},
+		{
+			name: "listed animations",
+			contents: `{
+				"frames": [
+					{"filename": "a.png", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}},
+					{"filename": "b.png", "frame": {"x": 43, "y": 0, "w": 43, "h": 43}}
+				],
+				"animations": {"sing": ["b.png", "a.png"]},
+				"meta": {"app": "https://www.codeandweb.com/texturepacker"}
+			}`,
+			want: map[string]*animationClip{
+				"Sing": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(43, 0, 86, 43), duration: defaultBirdFrameRate},
+					{rect: pixel.R(0, 0, 43, 43), duration: defaultBirdFrameRate},
+				}},
+			},
+		},
+		{
+			name: "trimmed frames keep their place",
+			contents: `{
+				"frames": {
+					"perch_0.png": {"frame": {"x": 43, "y": 5, "w": 30, "h": 38}, "rotated": false, "trimmed": true,
+						"spriteSourceSize": {"x": 13, "y": 5, "w": 30, "h": 38}, "sourceSize": {"w": 43, "h": 43}},
+					"perch_1.png": {"frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "rotated": false, "trimmed": false,
+						"spriteSourceSize": {"x": 0, "y": 0, "w": 43, "h": 43}, "sourceSize": {"w": 43, "h": 43}}
+				},
+				"meta": {"app": "https://www.codeandweb.com/texturepacker"}
+			}`,
+			want: map[string]*animationClip{
+				"Perch": {mode: loopPlayback, frames: []animationFrame{
+					{rect: pixel.R(43, 0, 73, 38), duration: defaultBirdFrameRate, untrimmedSize: pixel.V(43, 43), trimOffset: pixel.V(6.5, -2.5)},
+					{rect: pixel.R(0, 0, 43, 43), duration: defaultBirdFrameRate},
+				}},
+			},
+		},
+		{
+			name: "animation with a missing frame",
+			contents: `{
+				"frames": [{"filename": "a.png", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}}],
+				"animations": {"fly": ["a.png", "b.png"]},
+				"meta": {"app": "https://www.codeandweb.com/texturepacker"}
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "rotated frame",
+			contents: `{
+				"frames": [{"filename": "fly_0.png", "frame": {"x": 0, "y": 0, "w": 43, "h": 43}, "rotated": true}],
+				"meta": {"app": "https://www.codeandweb.com/texturepacker"}
+			}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if format := sniffAnimationFormat([]byte(test.contents)); format != texturePackerFormat {
+				t.Fatalf("sniffed format %v, want TexturePacker", format)
+			}
+
+			clips, err := parseTexturePackerSheet([]byte(test.contents), testSheetBounds)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(clips, test.want) {
+				t.Errorf("got %+v, want %+v", clips, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitFrameName(t *testing.T) {
+	tests := []struct {
+		filename string
+		name     string
+		number   int
+	}{
+		{"Fly_02.png", "Fly", 2},
+		{"eat-10.png", "eat", 10},
+		{"sing 3", "sing", 3},
+		{"perch.png", "perch", 0},
+	}
+
+	for _, test := range tests {
+		name, number := splitFrameName(test.filename)
+		if name != test.name || number != test.number {
+			t.Errorf("splitFrameName(%q) = %q, %d, want %q, %d", test.filename, name, number, test.name, test.number)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+type unlockKind int
+
+const (
+	seedUnlock unlockKind = iota
+	decorationUnlock
+)
+
+var unlockKindNames = map[unlockKind]string{
+	seedUnlock:       "Seed",
+	decorationUnlock: "Decoration",
+}
+
+// Something the economy can buy. Anything costing nothing is owned from the start.
+type shopItem struct {
+	name        string
+	kind        unlockKind
+	cost        int
+	description string
+}
+
+// A kind of seed, its price (in coins per feeder-full), and how much more (or less) likely it makes each species to
+// show up, by name.
+type seedType struct {
+	price       int
+	likelihoods map[string]float64
+}
+
+const defaultSeedTypeName = "Sunflower"
+
+var seedTypes = map[string]seedType{
+	defaultSeedTypeName: {price: 20},
+	"Safflower": {price: 30, likelihoods: map[string]float64{
+		"Northern Cardinal": 2,
+		"Tufted Titmouse":   1.5,
+	}},
+	"Suet": {price: 40, likelihoods: map[string]float64{
+		"Downy Woodpecker":       3,
+		"Black-capped Chickadee": 1.5,
+	}},
+}
+
+const birdbathName = "Birdbath"
+const brushPileName = "Brush pile"
+
+// Everything in the shop, in the order it's listed.
+var shopItems = []shopItem{
+	{name: defaultSeedTypeName, kind: seedUnlock, description: "a bit of everything"},
+	{name: "Safflower", kind: seedUnlock, cost: 150, description: "cardinals, titmice"},
+	{name: "Suet", kind: seedUnlock, cost: 250, description: "woodpeckers, chickadees"},
+	{name: brushPileName, kind: decorationUnlock, cost: 300, description: "birds come more often"},
+	{name: birdbathName, kind: decorationUnlock, cost: 400, description: "birds stay longer"},
+}
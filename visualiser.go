@@ -0,0 +1,220 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/cmplx"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// Draws the song being played as it plays: its waveform on top, and a scrolling spectrogram underneath. Handy for
+// seeing what can't be heard, and for learning songs by their shape.
+type songVisualiser struct {
+	name      string
+	buffer    *beep.Buffer
+	startTime time.Time
+
+	// The most recent window of samples played, mixed down to mono.
+	window []float64
+
+	// One column of band strengths (from 0 to 1) per update, oldest first.
+	spectrogram [][]float64
+
+	imd  *imdraw.IMDraw
+	text *text.Text
+}
+
+var visualiser = &songVisualiser{}
+
+// Show the given song from now on. The latest song to start is the one shown.
+func (visualiser *songVisualiser) follow(name string, buffer *beep.Buffer) {
+	if buffer == nil {
+		return
+	}
+
+	visualiser.name = name
+	visualiser.buffer = buffer
+	visualiser.startTime = time.Now()
+}
+
+func (visualiser *songVisualiser) update() {
+	if !songVisualisationEnabled {
+		visualiser.spectrogram = nil
+		return
+	}
+
+	visualiser.window = visualiser.readWindow()
+	visualiser.spectrogram = append(visualiser.spectrogram, spectrumBands(visualiser.window))
+
+	if len(visualiser.spectrogram) > visualiserColumns {
+		visualiser.spectrogram = visualiser.spectrogram[len(visualiser.spectrogram)-visualiserColumns:]
+	}
+}
+
+// The samples just played, or silence once the song has finished.
+func (visualiser *songVisualiser) readWindow() []float64 {
+	window := make([]float64, visualiserWindowLength)
+	if visualiser.buffer == nil {
+		return window
+	}
+
+	end := speakerSampleRate.N(time.Since(visualiser.startTime))
+	if end >= visualiser.buffer.Len() {
+		visualiser.buffer = nil
+		return window
+	}
+
+	start := end - visualiserWindowLength
+	if start < 0 {
+		start = 0
+	}
+
+	samples := make([][2]float64, end-start)
+	n, _ := visualiser.buffer.Streamer(start, end).Stream(samples)
+
+	// Line the samples up with the end of the window, so the start of a song comes in from the right.
+	offset := visualiserWindowLength - n
+	for i, sample := range samples[:n] {
+		window[offset+i] = (sample[0] + sample[1]) / 2
+	}
+
+	return window
+}
+
+// The strength of each frequency band in the window, from 0 to 1. Bands are spaced logarithmically, like hearing.
+func spectrumBands(window []float64) []float64 {
+	spectrum := make([]complex128, len(window))
+	for i, sample := range window {
+		// A Hann window keeps the edges from smearing across every band.
+		hann := .5 - .5*math.Cos(2*math.Pi*float64(i)/float64(len(window)-1))
+		spectrum[i] = complex(sample*hann, 0)
+	}
+	fft(spectrum)
+
+	bins := len(spectrum) / 2
+	bands := make([]float64, visualiserBands)
+	for band := range bands {
+		low := int(math.Pow(float64(bins), float64(band)/visualiserBands))
+		high := int(math.Pow(float64(bins), float64(band+1)/visualiserBands))
+		if high <= low {
+			high = low + 1
+		}
+
+		strongest := 0.0
+		for bin := low; bin < high && bin < bins; bin++ {
+			strongest = math.Max(strongest, cmplx.Abs(spectrum[bin]))
+		}
+
+		// Decibels, from the noise floor up to full scale (a full scale sine comes out of the window at a quarter of its length).
+		decibels := 20 * math.Log10(math.Max(strongest/(float64(len(window))/4), 1e-9))
+		bands[band] = math.Max(0, math.Min(1, (decibels-visualiserNoiseFloor)/-visualiserNoiseFloor))
+	}
+
+	return bands
+}
+
+// An in-place radix-2 fast Fourier transform. The length must be a power of two.
+func fft(values []complex128) {
+	n := len(values)
+
+	// Put the values in bit-reversed order.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(length)))
+		for start := 0; start < n; start += length {
+			twiddle := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				even := values[start+k]
+				odd := values[start+k+length/2] * twiddle
+				values[start+k] = even + odd
+				values[start+k+length/2] = even - odd
+				twiddle *= step
+			}
+		}
+	}
+}
+
+// Draw the panel in the bottom right of the screen.
+func (visualiser *songVisualiser) draw(target pixel.Target) {
+	if !songVisualisationEnabled {
+		return
+	}
+
+	if visualiser.imd == nil {
+		visualiser.imd = imdraw.New(nil)
+		visualiser.text = text.New(pixel.ZV, text.NewAtlas(basicfont.Face7x13, text.ASCII))
+	}
+	imd := visualiser.imd
+	imd.Clear()
+
+	min := pixel.V(layout.visibleBounds.Max.X-visualiserWidth-captionPadding, layout.visibleBounds.Min.Y+captionPadding)
+	max := min.Add(pixel.V(visualiserWidth, visualiserHeight))
+	middle := min.Y + visualiserHeight/2
+
+	imd.Color = color.RGBA{A: 190}
+	imd.Push(min, max)
+	imd.Rectangle(0)
+
+	// The spectrogram fills the bottom half, newest on the right.
+	columnWidth := visualiserWidth / float64(visualiserColumns)
+	bandHeight := (visualiserHeight / 2) / float64(visualiserBands)
+	startColumn := visualiserColumns - len(visualiser.spectrogram)
+	for column, bands := range visualiser.spectrogram {
+		for band, strength := range bands {
+			if strength == 0 {
+				continue
+			}
+
+			bottomLeft := pixel.V(min.X+float64(startColumn+column)*columnWidth, min.Y+float64(band)*bandHeight)
+			imd.Color = spectrogramColor(strength)
+			imd.Push(bottomLeft, bottomLeft.Add(pixel.V(columnWidth, bandHeight)))
+			imd.Rectangle(0)
+		}
+	}
+
+	// The waveform fills the top half.
+	imd.Color = color.RGBA{R: 120, G: 220, B: 150, A: 255}
+	if len(visualiser.window) > 0 {
+		for x := 0.0; x <= visualiserWidth; x += 2 {
+			sample := visualiser.window[int(x/visualiserWidth*float64(len(visualiser.window)-1))]
+			imd.Push(pixel.V(min.X+x, middle+visualiserHeight/4+sample*visualiserHeight/4))
+		}
+		imd.Line(1)
+	}
+
+	imd.Draw(target)
+
+	// Label the panel with whoever's being shown.
+	visualiser.text.Clear()
+	visualiser.text.Color = color.White
+	visualiser.text.Dot = pixel.V(min.X+captionPadding, max.Y-captionPadding-visualiser.text.Atlas().LineHeight())
+	visualiser.text.WriteString(visualiser.name)
+	visualiser.text.Draw(target, pixel.IM)
+}
+
+// From dark blue for quiet, through to yellow for loud.
+func spectrogramColor(strength float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(255 * math.Min(1, strength*1.5)),
+		G: uint8(255 * strength * strength),
+		B: uint8(255 * (1 - strength) * .6),
+		A: 255,
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+// Birds waiting off the feeder for a perch to free up, in order of arrival.
+var waitingBirds []*bird
+
+func enqueueWaitingBird(bird *bird) {
+	for _, waitingBird := range waitingBirds {
+		if waitingBird == bird {
+			return
+		}
+	}
+
+	waitingBirds = append(waitingBirds, bird)
+}
+
+func dequeueWaitingBird(bird *bird) {
+	for index, waitingBird := range waitingBirds {
+		if waitingBird == bird {
+			waitingBirds = append(waitingBirds[:index], waitingBirds[index+1:]...)
+			return
+		}
+	}
+}
+
+// Send the longest waiting bird that fits over to a perch that was just freed.
+func promoteWaitingBird(freedPerch *perch) {
+	perchWidth := freedPerch.X.max - freedPerch.X.min
+	perchHeight := freedPerch.Y.max - freedPerch.Y.min
+
+	for _, waitingBird := range waitingBirds {
+		if perchWidth >= waitingBird.species.Width() && perchHeight >= waitingBird.species.Height() {
+			dequeueWaitingBird(waitingBird)
+			waitingBird.flyToPerch(freedPerch)
+			return
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	wr "github.com/mroth/weightedrand"
+)
+
+type weatherCondition int
+
+const (
+	clearSkies weatherCondition = iota
+	overcast
+	rain
+	snow
+	windy
+	fog
+)
+
+var weatherNames = map[weatherCondition]string{
+	clearSkies: "Clear",
+	overcast:   "Overcast",
+	rain:       "Rain",
+	snow:       "Snow",
+	windy:      "Windy",
+	fog:        "Fog",
+}
+
+// How a weather condition changes the look and behaviour of the feeder.
+type weatherEffect struct {
+	// Percentages applied to how often birds show up, how long they eat and how likely they are to sing.
+	activityPercent uint
+	eatingPercent   uint
+	singingPercent  uint
+
+	// Colour (alpha-premultiplied) laid over the whole scene.
+	tint color.RGBA
+
+	// Number of particles (rain drops, snowflakes, gusts) on screen at once.
+	particleCount int
+}
+
+var weatherEffects = map[weatherCondition]weatherEffect{
+	clearSkies: {activityPercent: 100, eatingPercent: 100, singingPercent: 100, tint: color.RGBA{}},
+	overcast:   {activityPercent: 100, eatingPercent: 100, singingPercent: 80, tint: color.RGBA{R: 20, G: 20, B: 30, A: 60}},
+	rain:       {activityPercent: 40, eatingPercent: 80, singingPercent: 20, tint: color.RGBA{R: 10, G: 15, B: 35, A: 90}, particleCount: 300},
+	snow:       {activityPercent: 80, eatingPercent: 140, singingPercent: 40, tint: color.RGBA{R: 45, G: 45, B: 50, A: 50}, particleCount: 200},
+	windy:      {activityPercent: 60, eatingPercent: 90, singingPercent: 60, tint: color.RGBA{}, particleCount: 40},
+	fog:        {activityPercent: 80, eatingPercent: 100, singingPercent: 70, tint: color.RGBA{R: 100, G: 100, B: 105, A: 110}},
+}
+
+// Likelihood of each weather condition following another.
+var weatherTransitions = map[weatherCondition]map[weatherCondition]uint{
+	clearSkies: {clearSkies: 500, overcast: 300, windy: 150, fog: 50},
+	overcast:   {clearSkies: 250, overcast: 250, rain: 300, snow: 100, windy: 100},
+	rain:       {overcast: 500, rain: 300, windy: 100, fog: 100},
+	snow:       {overcast: 500, snow: 400, windy: 100},
+	windy:      {clearSkies: 400, overcast: 400, rain: 200},
+	fog:        {clearSkies: 500, overcast: 500},
+}
+
+// How long each weather condition lasts, in minutes.
+var weatherLengthRanges = map[weatherCondition]pair{
+	clearSkies: {60, 240},
+	overcast:   {30, 180},
+	rain:       {20, 120},
+	snow:       {30, 180},
+	windy:      {20, 90},
+	fog:        {30, 120},
+}
+
+type weatherParticle struct {
+	position pixel.Vec
+	velocity pixel.Vec
+}
+
+type weatherSystem struct {
+	current weatherCondition
+
+	// The weather coming next, and when it arrives. Birds can tell when a storm is on its way.
+	next           weatherCondition
+	nextChangeTime time.Time
+
+	particles []*weatherParticle
+
+	// Where the real weather is read from, if anywhere, and when to next read it.
+	provider     weatherProvider
+	nextPollTime time.Time
+	polling      bool
+	readings     chan weatherReading
+}
+
+var weather = &weatherSystem{}
+
+func (weather *weatherSystem) Initialize() {
+	weather.changeTo(clearSkies)
+
+	// Read the real weather straight away when there's somewhere to read it from.
+	weather.provider = resolveWeatherProvider()
+	weather.readings = make(chan weatherReading, 1)
+	if weather.provider != nil {
+		weather.poll()
+	}
+}
+
+func (weather *weatherSystem) update(elapsed float64) {
+	if weather.provider != nil {
+		select {
+		case reading := <-weather.readings:
+			weather.applyReading(reading)
+		default:
+		}
+
+		if time.Now().After(weather.nextPollTime) {
+			weather.poll()
+		}
+	}
+
+	if time.Now().After(weather.nextChangeTime) {
+		weather.changeTo(weather.next)
+	}
+
+	weather.updateParticles(elapsed)
+}
+
+func (weather *weatherSystem) changeTo(condition weatherCondition) {
+	weather.current = condition
+	weather.next = pickNextWeather(condition)
+
+	// Fill the whole screen with the new weather's particles straight away.
+	weather.particles = nil
+	for len(weather.particles) < weatherEffects[condition].particleCount {
+		weather.particles = append(weather.particles, weather.newParticle(true))
+	}
+
+	length := weatherLengthRanges[condition]
+	weather.nextChangeTime = time.Now().Add(time.Minute * time.Duration(nextRandomInt(length.min, length.max)))
+}
+
+func pickNextWeather(condition weatherCondition) weatherCondition {
+	winterTime := getSeason(time.Now()) == winter
+
+	// Enumerate all following weather choices.
+	choices := []wr.Choice{}
+	for nextCondition, likelihood := range weatherTransitions[condition] {
+		// It only snows in winter, and in winter precipitation falls as snow.
+		if nextCondition == snow && !winterTime {
+			nextCondition = rain
+		} else if nextCondition == rain && winterTime {
+			nextCondition = snow
+		}
+
+		choices = append(choices, wr.Choice{Item: nextCondition, Weight: likelihood})
+	}
+
+	// Initialize a weighted probability weather chooser.
+	chooser, err := wr.NewChooser(choices...)
+	if err != nil {
+		return clearSkies
+	}
+
+	return chooser.Pick().(weatherCondition)
+}
+
+// Birds feed up just before rain or snow arrives.
+func (weather *weatherSystem) stormApproaching() bool {
+	return (weather.next == rain || weather.next == snow) && time.Until(weather.nextChangeTime) < stormWarningLength
+}
+
+func (weather *weatherSystem) effect() weatherEffect {
+	effect := weatherEffects[weather.current]
+
+	if weather.stormApproaching() {
+		effect.activityPercent = stormActivityPercent
+		effect.eatingPercent = stormEatingPercent
+	}
+
+	return effect
+}
+
+// Shift the time length likelihoods towards shorter lengths the more active the birds are, and towards longer ones
+// the less active they are.
+func (weather *weatherSystem) timeLikelihoods(likelihoods map[timeLength]uint) map[timeLength]uint {
+	activityPercent := weather.effect().activityPercent
+	adjusted := map[timeLength]uint{}
+
+	for category, likelihood := range likelihoods {
+		switch category {
+		case veryShort, short:
+			adjusted[category] = likelihood * activityPercent / 100
+		case long, veryLong, insane:
+			adjusted[category] = likelihood * 100 / activityPercent
+		default:
+			adjusted[category] = likelihood
+		}
+	}
+
+	return adjusted
+}
+
+func (weather *weatherSystem) updateParticles(elapsed float64) {
+	// Move the particles, dropping any that leave the screen.
+	remainingParticles := weather.particles[:0]
+	for _, particle := range weather.particles {
+		particle.position = particle.position.Add(particle.velocity.Scaled(elapsed))
+
+		if particle.position.Y >= layout.canvasBounds.Min.Y && particle.position.X >= layout.canvasBounds.Min.X && particle.position.X <= layout.canvasBounds.Max.X {
+			remainingParticles = append(remainingParticles, particle)
+		}
+	}
+
+	weather.particles = remainingParticles
+
+	// Top up the particles for the current weather.
+	for len(weather.particles) < weatherEffects[weather.current].particleCount {
+		weather.particles = append(weather.particles, weather.newParticle(false))
+	}
+}
+
+func (weather *weatherSystem) newParticle(anywhere bool) *weatherParticle {
+	// New particles start along the top (or left edge, for gusts) unless they're filling an empty screen.
+	bounds := layout.canvasBounds
+	position := pixel.V(nextRandomFloat64(bounds.Min.X, bounds.Max.X), bounds.Max.Y)
+	if anywhere {
+		position.Y = nextRandomFloat64(bounds.Min.Y, bounds.Max.Y)
+	}
+
+	switch weather.current {
+	case rain:
+		return &weatherParticle{position: position, velocity: pixel.V(-80, nextRandomFloat64(-1300, -900))}
+	case snow:
+		return &weatherParticle{position: position, velocity: pixel.V(nextRandomFloat64(-40, 40), nextRandomFloat64(-90, -50))}
+	case windy:
+		position.X = bounds.Min.X
+		if anywhere {
+			position.X = nextRandomFloat64(bounds.Min.X, bounds.Max.X)
+		}
+
+		return &weatherParticle{position: position, velocity: pixel.V(nextRandomFloat64(900, 1400), nextRandomFloat64(-60, 60))}
+	}
+
+	return &weatherParticle{position: position}
+}
+
+func (weather *weatherSystem) draw(imd *imdraw.IMDraw) {
+	// Draw the particles.
+	for _, particle := range weather.particles {
+		switch weather.current {
+		case rain:
+			imd.Color = color.RGBA{R: 105, G: 120, B: 145, A: 160}
+			imd.Push(particle.position, particle.position.Add(particle.velocity.Scaled(.015)))
+			imd.Line(2)
+		case snow:
+			imd.Color = color.RGBA{R: 225, G: 225, B: 230, A: 230}
+			imd.Push(particle.position)
+			imd.Circle(3, 0)
+		case windy:
+			imd.Color = color.RGBA{R: 65, G: 65, B: 65, A: 70}
+			imd.Push(particle.position, particle.position.Add(particle.velocity.Scaled(.05)))
+			imd.Line(1)
+		}
+	}
+
+	// Tint the whole scene.
+	tint := weatherEffects[weather.current].tint
+	if tint.A != 0 {
+		imd.Color = tint
+		imd.Push(layout.canvasBounds.Min, layout.canvasBounds.Max)
+		imd.Rectangle(0)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var weatherFileFlag = flag.String("weather-file", "", "read the weather from this JSON file instead of simulating it")
+var weatherURLFlag = flag.String("weather-url", "", "read the weather from this HTTP endpoint instead of simulating it")
+
+// Somewhere the real weather outside can be read from. When there is no provider (or it fails), the weather is simulated.
+type weatherProvider interface {
+	Name() string
+	Report() (weatherReport, error)
+}
+
+// The weather as reported by a provider, e.g. {"condition": "rain", "forecast": "clear", "forecastMinutes": 45}.
+// The forecast is optional, and lets birds know when a storm is on its way.
+type weatherReport struct {
+	Condition       string `json:"condition"`
+	Forecast        string `json:"forecast"`
+	ForecastMinutes int    `json:"forecastMinutes"`
+}
+
+// Accepted names for each weather condition, in addition to the ones in weatherNames.
+var weatherConditionAliases = map[string]weatherCondition{
+	"sunny":   clearSkies,
+	"cloudy":  overcast,
+	"drizzle": rain,
+	"storm":   rain,
+	"sleet":   snow,
+	"wind":    windy,
+	"mist":    fog,
+}
+
+func parseWeatherCondition(name string) (weatherCondition, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for condition, conditionName := range weatherNames {
+		if strings.ToLower(conditionName) == name {
+			return condition, nil
+		}
+	}
+
+	if condition, found := weatherConditionAliases[name]; found {
+		return condition, nil
+	}
+
+	return clearSkies, fmt.Errorf("unknown weather condition %q", name)
+}
+
+func decodeWeatherReport(reader io.Reader) (weatherReport, error) {
+	report := weatherReport{}
+	if err := json.NewDecoder(reader).Decode(&report); err != nil {
+		return weatherReport{}, err
+	}
+
+	// Make sure the conditions are ones we know about.
+	if _, err := parseWeatherCondition(report.Condition); err != nil {
+		return weatherReport{}, err
+	}
+
+	if report.Forecast != "" {
+		if _, err := parseWeatherCondition(report.Forecast); err != nil {
+			return weatherReport{}, err
+		}
+	}
+
+	return report, nil
+}
+
+// Reads the weather from a local JSON file, e.g. one kept up to date by a home weather station script.
+type fileWeatherProvider struct {
+	path string
+}
+
+func (provider *fileWeatherProvider) Name() string {
+	return "file " + provider.path
+}
+
+func (provider *fileWeatherProvider) Report() (weatherReport, error) {
+	file, err := os.Open(provider.path)
+	if err != nil {
+		return weatherReport{}, err
+	}
+	defer file.Close()
+
+	return decodeWeatherReport(file)
+}
+
+// Reads the weather from an HTTP endpoint serving the same JSON as the file provider.
+type httpWeatherProvider struct {
+	url    string
+	client *http.Client
+}
+
+func (provider *httpWeatherProvider) Name() string {
+	return "endpoint " + provider.url
+}
+
+func (provider *httpWeatherProvider) Report() (weatherReport, error) {
+	resp, err := provider.client.Get(provider.url)
+	if err != nil {
+		return weatherReport{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Drain the body so the connection can be reused.
+		ioutil.ReadAll(resp.Body)
+		return weatherReport{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return decodeWeatherReport(resp.Body)
+}
+
+// The provider picked on the command line, if any.
+func resolveWeatherProvider() weatherProvider {
+	if *weatherFileFlag != "" {
+		return &fileWeatherProvider{path: *weatherFileFlag}
+	}
+
+	if *weatherURLFlag != "" {
+		return &httpWeatherProvider{url: *weatherURLFlag, client: &http.Client{Timeout: weatherProviderTimeout}}
+	}
+
+	return nil
+}
+
+// The result of reading a weather provider.
+type weatherReading struct {
+	report weatherReport
+	err    error
+}
+
+// Read the provider in the background so that a slow endpoint never holds up a frame.
+func (weather *weatherSystem) poll() {
+	weather.nextPollTime = time.Now().Add(weatherPollInterval)
+
+	if weather.polling {
+		return
+	}
+
+	weather.polling = true
+	go func(provider weatherProvider, readings chan weatherReading) {
+		report, err := provider.Report()
+		readings <- weatherReading{report: report, err: err}
+	}(weather.provider, weather.readings)
+}
+
+func (weather *weatherSystem) applyReading(reading weatherReading) {
+	weather.polling = false
+
+	if reading.err != nil {
+		// Carry on with the simulated weather until the provider comes back.
+		fmt.Println("could not read the weather from " + weather.provider.Name() + ", simulating it instead: " + reading.err.Error())
+		return
+	}
+
+	condition, _ := parseWeatherCondition(reading.report.Condition)
+	if condition != weather.current {
+		weather.changeTo(condition)
+	}
+
+	// Without a forecast, assume things stay as they are. If the provider goes quiet for long enough,
+	// the simulation takes over again.
+	weather.next = condition
+	weather.nextChangeTime = time.Now().Add(weatherReportLifetime)
+
+	if reading.report.Forecast != "" {
+		weather.next, _ = parseWeatherCondition(reading.report.Forecast)
+
+		if reading.report.ForecastMinutes > 0 {
+			weather.nextChangeTime = time.Now().Add(time.Minute * time.Duration(reading.report.ForecastMinutes))
+		}
+	}
+}
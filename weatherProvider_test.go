@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// An endpoint answering every request with the given status and body.
+func testWeatherServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestDecodeWeatherReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    weatherReport
+		wantErr bool
+	}{
+		{"condition only", `{"condition": "Rain"}`, weatherReport{Condition: "Rain"}, false},
+		{"alias", `{"condition": "sunny"}`, weatherReport{Condition: "sunny"}, false},
+		{"forecast", `{"condition": "overcast", "forecast": "snow", "forecastMinutes": 45}`, weatherReport{Condition: "overcast", Forecast: "snow", ForecastMinutes: 45}, false},
+		{"malformed body", `{"condition": `, weatherReport{}, true},
+		{"unknown condition", `{"condition": "hail"}`, weatherReport{}, true},
+		{"unknown forecast", `{"condition": "rain", "forecast": "locusts"}`, weatherReport{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := decodeWeatherReport(strings.NewReader(test.body))
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: error %v, want error %v", test.name, err, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("%s: report %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHTTPWeatherProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    weatherReport
+		wantErr bool
+	}{
+		{"good report", http.StatusOK, `{"condition": "fog", "forecast": "clear", "forecastMinutes": 20}`, weatherReport{Condition: "fog", Forecast: "clear", ForecastMinutes: 20}, false},
+		{"malformed body", http.StatusOK, `<html>not json</html>`, weatherReport{}, true},
+		{"unknown condition", http.StatusOK, `{"condition": "hail"}`, weatherReport{}, true},
+		{"non-200 status", http.StatusServiceUnavailable, `{"condition": "rain"}`, weatherReport{}, true},
+	}
+
+	for _, test := range tests {
+		server := testWeatherServer(test.status, test.body)
+		provider := &httpWeatherProvider{url: server.URL, client: server.Client()}
+
+		got, err := provider.Report()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: error %v, want error %v", test.name, err, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("%s: report %+v, want %+v", test.name, got, test.want)
+		}
+
+		server.Close()
+	}
+}
+
+func TestFileWeatherProvider(t *testing.T) {
+	directory, err := ioutil.TempDir("", "feedr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "weather.json")
+	if err := ioutil.WriteFile(path, []byte(`{"condition": "windy"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := (&fileWeatherProvider{path: path}).Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Condition != "windy" {
+		t.Errorf("condition %q, want %q", report.Condition, "windy")
+	}
+
+	if _, err := (&fileWeatherProvider{path: filepath.Join(directory, "missing.json")}).Report(); err == nil {
+		t.Error("no error reading a missing file")
+	}
+}
+
+func TestResolveWeatherProvider(t *testing.T) {
+	defer func(file, url string) {
+		*weatherFileFlag, *weatherURLFlag = file, url
+	}(*weatherFileFlag, *weatherURLFlag)
+
+	*weatherFileFlag, *weatherURLFlag = "", ""
+	if provider := resolveWeatherProvider(); provider != nil {
+		t.Errorf("provider %s without any flags", provider.Name())
+	}
+
+	*weatherURLFlag = "http://localhost/weather"
+	if _, ok := resolveWeatherProvider().(*httpWeatherProvider); !ok {
+		t.Error("no endpoint provider for -weather-url")
+	}
+
+	// The file wins when both are given.
+	*weatherFileFlag = "weather.json"
+	if _, ok := resolveWeatherProvider().(*fileWeatherProvider); !ok {
+		t.Error("no file provider for -weather-file")
+	}
+}
+
+// A report holds the weather for weatherReportLifetime. Once the provider stops answering, the simulation takes over.
+func TestWeatherFallsBackToSimulation(t *testing.T) {
+	goodServer := testWeatherServer(http.StatusOK, `{"condition": "overcast"}`)
+	defer goodServer.Close()
+	failingServer := testWeatherServer(http.StatusInternalServerError, "")
+	defer failingServer.Close()
+
+	weather := &weatherSystem{}
+	weather.changeTo(clearSkies)
+	weather.provider = &httpWeatherProvider{url: goodServer.URL, client: goodServer.Client()}
+	weather.readings = make(chan weatherReading, 1)
+	weather.nextPollTime = time.Now().Add(time.Hour)
+
+	report, err := weather.provider.Report()
+	weather.applyReading(weatherReading{report: report, err: err})
+
+	if weather.current != overcast || weather.next != overcast {
+		t.Fatalf("weather %s then %s after an overcast report", weatherNames[weather.current], weatherNames[weather.next])
+	}
+	if held := time.Until(weather.nextChangeTime); held <= weatherReportLifetime-time.Minute || held > weatherReportLifetime {
+		t.Errorf("report held for %v, want %v", held, weatherReportLifetime)
+	}
+
+	// The provider fails, which leaves the last report standing until it runs out.
+	weather.provider = &httpWeatherProvider{url: failingServer.URL, client: failingServer.Client()}
+	report, err = weather.provider.Report()
+	weather.readings <- weatherReading{report: report, err: err}
+	weather.update(0)
+
+	if weather.current != overcast {
+		t.Errorf("weather %s before the report ran out", weatherNames[weather.current])
+	}
+
+	// Once it runs out, the weather changes on its own again.
+	weather.nextChangeTime = time.Now().Add(-time.Second)
+	weather.update(0)
+
+	length := weatherLengthRanges[weather.current]
+	lasts := time.Until(weather.nextChangeTime)
+	if lasts < time.Duration(length.min-1)*time.Minute || lasts > time.Duration(length.max)*time.Minute {
+		t.Errorf("simulated %s lasts %v, want %d to %d minutes", weatherNames[weather.current], lasts, length.min, length.max)
+	}
+}